@@ -0,0 +1,67 @@
+package errors
+
+// Attr is a key-value pair attached to an error, e.g. request ID, tenant ID etc.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// Attribute returns an Attr for the given key and value
+func Attribute(key string, value any) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Attributes returns the attributes attached to the error, excluding the ones of wrapped errors
+func (e *Error) Attributes() []Attr {
+	if len(e.attrs) == 0 {
+		return nil
+	}
+	attrs := make([]Attr, len(e.attrs))
+	copy(attrs, e.attrs)
+	return attrs
+}
+
+// Attributes returns the attributes of all the *Error in the chain, including joined errors. If the
+// same key is set more than once, only the outermost one is returned
+func Attributes(err error) []Attr {
+	attrs := make([]Attr, 0, 8)
+	lookup := map[string]struct{}{}
+	walk(err, func(e *Error) bool {
+		for _, attr := range e.attrs {
+			if _, ok := lookup[attr.Key]; ok {
+				continue
+			}
+			lookup[attr.Key] = struct{}{}
+			attrs = append(attrs, attr)
+		}
+		return true
+	})
+
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	return attrs
+}
+
+// walk calls fn for every *Error in the error tree, depth first starting from err. It stops if fn
+// returns false
+func walk(err error, fn func(e *Error) bool) bool {
+	for err != nil {
+		switch e := unembed(err).(type) {
+		case *Error:
+			if !fn(e) {
+				return false
+			}
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				if !walk(inner, fn) {
+					return false
+				}
+			}
+			return true
+		}
+		err = Unwrap(err)
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity is the severity of an error, e.g. to decide the log level or alerting
+type Severity int
+
+const (
+	// SeverityUnset is the severity of errors for which it was not set
+	SeverityUnset Severity = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+// String returns the name of the severity, as used by Sentry, e.g. "warning"
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	}
+	return ""
+}
+
+// Builder is used to compose errors with multiple properties, e.g.
+// errors.Build().Type(errors.TypeNotFound).Code("user_not_found").Attr("user_id", id).Wrap(err, "user not found")
+// Builder is immutable, every method returns a new Builder; so a partially configured Builder can
+// be reused. The stacktrace is captured by the terminal methods: New, Newf, Wrap & Wrapf
+type Builder struct {
+	factory    *Factory
+	eType      errType
+	hasType    bool
+	code       string
+	attrs      []Attr
+	severity   Severity
+	retry      bool
+	retryAfter time.Duration
+	internal   string
+	// payload, msgKey & msgArgs are set by the constructors of PayloadError & localized errors, so
+	// that the error is complete before the hooks are called
+	payload any
+	msgKey  string
+	msgArgs []any
+}
+
+// Build returns a Builder which uses the default factory
+func Build() Builder {
+	return Builder{}
+}
+
+// Build returns a Builder which uses the factory
+func (f *Factory) Build() Builder {
+	return Builder{factory: f}
+}
+
+// Type sets the type of the error. If not set, New & Newf use the default type of the factory and
+// Wrap & Wrapf use the type of the wrapped error
+func (b Builder) Type(et errType) Builder {
+	b.eType = et
+	b.hasType = true
+	return b
+}
+
+// Code sets the application specific code of the error
+func (b Builder) Code(code string) Builder {
+	b.code = code
+	return b
+}
+
+// Attr adds an attribute to the error
+func (b Builder) Attr(key string, value any) Builder {
+	b.attrs = append(b.attrs[:len(b.attrs):len(b.attrs)], Attribute(key, value))
+	return b
+}
+
+// Attrs adds the attributes to the error
+func (b Builder) Attrs(attrs ...Attr) Builder {
+	b.attrs = append(b.attrs[:len(b.attrs):len(b.attrs)], attrs...)
+	return b
+}
+
+// Severity sets the severity of the error
+func (b Builder) Severity(s Severity) Builder {
+	b.severity = s
+	return b
+}
+
+// Retry marks the error as retryable, after the given duration. Zero means it can be retried
+// immediately
+func (b Builder) Retry(after time.Duration) Builder {
+	b.retry = true
+	b.retryAfter = after
+	return b
+}
+
+// InternalMessage sets a message meant only for developers. Unlike the message provided to the
+// terminal methods, it's included only in the verbose formats, i.e. '%+v' & the stacktraces; and
+// not in Error(), Message() or the serializers. So it's never sent to users
+func (b Builder) InternalMessage(msg string) Builder {
+	b.internal = msg
+	return b
+}
+
+// withPayload sets the payload of the error, refer PayloadError
+func (b Builder) withPayload(payload any) Builder {
+	b.payload = payload
+	return b
+}
+
+// localized sets the message key & arguments of the error, refer NewLocalized
+func (b Builder) localized(key string, args []any) Builder {
+	b.msgKey = key
+	b.msgArgs = args
+	return b
+}
+
+// New returns a new error with the message
+func (b Builder) New(msg string) *Error {
+	return b.newerr(nil, msg, 3)
+}
+
+// Newf returns a new error with the formatted message
+func (b Builder) Newf(format string, args ...any) *Error {
+	return b.newerrf(nil, 4, format, args...)
+}
+
+// Wrap wraps the original error with the message
+func (b Builder) Wrap(original error, msg ...string) *Error {
+	return b.newerr(original, strings.Join(msg, ". "), 3)
+}
+
+// Wrapf wraps the original error with the formatted message
+func (b Builder) Wrapf(original error, format string, args ...any) *Error {
+	return b.newerrf(original, 4, format, args...)
+}
+
+func (b Builder) getFactory() *Factory {
+	if b.factory != nil {
+		return b.factory
+	}
+	return Default()
+}
+
+func (b Builder) errType(f *Factory, original error) errType {
+	switch {
+	case b.hasType:
+		return b.eType
+	case original != nil:
+		return f.errType(original)
+	}
+	return f.config.DefaultType
+}
+
+func (b Builder) newerr(original error, message string, skip int) *Error {
+	f := b.getFactory()
+	err := f.capture(original, message, b.errType(f, original), skip+1)
+	return b.finish(f, err)
+}
+
+func (b Builder) newerrf(original error, skip int, format string, args ...any) *Error {
+	f := b.getFactory()
+	err := f.capture(original, fmt.Sprintf(format, args...), b.errType(f, original), skip)
+	err.format = format
+	if hasSensitive(args) {
+		err.args = args
+	}
+	return b.finish(f, err)
+}
+
+func (b Builder) finish(f *Factory, err *Error) *Error {
+	err.code = b.code
+	err.severity = b.severity
+	err.retryable = b.retry
+	err.retryAfter = b.retryAfter
+	err.internal = b.internal
+	err.payload = b.payload
+	err.msgKey = b.msgKey
+	err.msgArgs = b.msgArgs
+	if len(b.attrs) > 0 {
+		err.attrs = append([]Attr(nil), b.attrs...)
+	}
+	f.runHooks(err)
+	return err
+}
+
+// Severity returns the severity of the error
+func (e *Error) Severity() Severity {
+	return e.severity
+}
+
+// Retry returns the duration after which the operation can be retried, the boolean is true if the
+// error is retryable
+func (e *Error) Retry() (time.Duration, bool) {
+	return e.retryAfter, e.retryable
+}
+
+// InternalMessage returns the message meant only for developers, refer Builder.InternalMessage
+func (e *Error) InternalMessage() string {
+	return e.internal
+}
+
+// SeverityOf returns the severity of the outermost *Error in the error tree which has it set
+func SeverityOf(err error) Severity {
+	severity := SeverityUnset
+	walk(err, func(e *Error) bool {
+		severity = e.severity
+		return severity == SeverityUnset
+	})
+	return severity
+}
+
+// RetryAfter returns the retry duration of the outermost retryable *Error in the error tree, the
+// boolean is true if there's a retryable error
+func RetryAfter(err error) (time.Duration, bool) {
+	var (
+		after     time.Duration
+		retryable bool
+	)
+	walk(err, func(e *Error) bool {
+		after, retryable = e.Retry()
+		return !retryable
+	})
+	return after, retryable
+}
@@ -0,0 +1,157 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	base := Build().Type(TypeNotFound).Code("user_not_found").Attr("user_id", 10)
+	err := base.Severity(SeverityWarning).
+		Retry(time.Second).
+		InternalMessage("no rows in users table").
+		Wrap(errors.New("sql: no rows"), "user not found")
+
+	if err.Type() != TypeNotFound || err.Code() != "user_not_found" {
+		t.Errorf("unexpected type %v, code %q", err.Type(), err.Code())
+	}
+	if err.Severity() != SeverityWarning {
+		t.Errorf("Severity() = %v, want %v", err.Severity(), SeverityWarning)
+	}
+	if after, ok := err.Retry(); !ok || after != time.Second {
+		t.Errorf("Retry() = (%v, %v), want (1s, true)", after, ok)
+	}
+	if got := err.Message(); got != "user not found" {
+		t.Errorf("Message() = %q, internal message should not be included", got)
+	}
+	if got := err.InternalMessage(); got != "no rows in users table" {
+		t.Errorf("InternalMessage() = %q", got)
+	}
+	if !strings.Contains(err.Error(), "builder_test.go") ||
+		strings.Contains(err.Error(), "no rows in users table") {
+		t.Errorf("Error() = %q, internal message should not be included", err.Error())
+	}
+	if got := err.ErrorWithoutFileLine(); got != "user not found: sql: no rows" {
+		t.Errorf("ErrorWithoutFileLine() = %q", got)
+	}
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "user not found: no rows in users table") {
+		t.Errorf("%%+v = %q, expected the internal message", got)
+	}
+	if got := Stacktrace(err); !strings.Contains(got, "user not found: no rows in users table") {
+		t.Errorf("Stacktrace() = %q, expected the internal message", got)
+	}
+
+	// the builder is immutable, so it can be reused
+	other := base.Attr("tenant", "acme").New("other")
+	if attrs := other.Attributes(); len(attrs) != 2 {
+		t.Errorf("Attributes() = %v", attrs)
+	}
+	if attrs := err.Attributes(); len(attrs) != 1 {
+		t.Errorf("Attributes() = %v, the builder should not be mutated", attrs)
+	}
+	if other.Severity() != SeverityUnset || other.InternalMessage() != "" {
+		t.Errorf("builder was mutated")
+	}
+}
+
+func TestBuilderTerminals(t *testing.T) {
+	f := NewFactory(Config{DefaultType: TypeValidation})
+	tests := []struct {
+		name    string
+		err     *Error
+		etype   errType
+		message string
+		format  string
+	}{
+		{name: "New", err: Build().New("hello"), etype: TypeInternal, message: "hello"},
+		{name: "Newf", err: Build().Newf("hello %s", "world"), etype: TypeInternal, message: "hello world", format: "hello %s"},
+		{name: "Wrap", err: Build().Wrap(Duplicate("dup"), "hello"), etype: TypeDuplicate, message: "hello: dup"},
+		{name: "Wrapf", err: Build().Type(TypeEmpty).Wrapf(Duplicate("dup"), "hello %d", 1), etype: TypeEmpty, message: "hello 1: dup", format: "hello %d"},
+		{name: "factory", err: f.Build().New("hello"), etype: TypeValidation, message: "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Type() != tt.etype {
+				t.Errorf("Type() = %v, want %v", tt.err.Type(), tt.etype)
+			}
+			if got := tt.err.Message(); got != tt.message {
+				t.Errorf("Message() = %q, want %q", got, tt.message)
+			}
+			if tt.err.format != tt.format {
+				t.Errorf("format = %q, want %q", tt.err.format, tt.format)
+			}
+			caller := tt.err.Frames()[0]
+			if caller.Function != "github.com/naughtygopher/errors.TestBuilderTerminals" {
+				t.Errorf("stacktrace should start at the call site, got %s", caller.Function)
+			}
+		})
+	}
+}
+
+func TestBuilderSerialization(t *testing.T) {
+	err := Wrap(Build().Severity(SeverityFatal).Retry(2*time.Second).InternalMessage("disk full").New("write failed"), "save")
+	if got := SeverityOf(err); got != SeverityFatal {
+		t.Errorf("SeverityOf() = %v, want %v", got, SeverityFatal)
+	}
+	if after, ok := RetryAfter(err); !ok || after != 2*time.Second {
+		t.Errorf("RetryAfter() = (%v, %v), want (2s, true)", after, ok)
+	}
+	if after, ok := RetryAfter(New("hello")); ok || after != 0 {
+		t.Errorf("RetryAfter() = (%v, %v), want (0, false)", after, ok)
+	}
+
+	raw, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+	if strings.Contains(string(raw), "disk full") {
+		t.Errorf("json.Marshal() = %s, internal message should not be included", raw)
+	}
+	for _, want := range []string{
+		`"severity":"fatal"`,
+		`"retryable":true`,
+		`"retry_after":"2s"`,
+	} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("json.Marshal() = %s, expected %s", raw, want)
+		}
+	}
+
+	if got := NewSentryEvent(err).Level; got != "fatal" {
+		t.Errorf("NewSentryEvent().Level = %q, want fatal", got)
+	}
+	if got := NewSentryEvent(fmt.Errorf("oops")).Level; got != "error" {
+		t.Errorf("NewSentryEvent().Level = %q, want error", got)
+	}
+}
+
+func TestBuilderInternalMessageLeak(t *testing.T) {
+	err := Build().InternalMessage("db password=hunter2").New("")
+
+	rr := httptest.NewRecorder()
+	WriteHTTP(err, rr)
+	raw, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+
+	for name, got := range map[string]string{
+		"Error()":        err.Error(),
+		"Message()":      err.Message(),
+		"WriteHTTP body": rr.Body.String(),
+		"json.Marshal()": string(raw),
+	} {
+		if strings.Contains(got, "hunter2") {
+			t.Errorf("%s = %q, internal message should not be included", name, got)
+		}
+	}
+
+	if got := fmt.Sprintf("%+v", err); !strings.Contains(got, "hunter2") {
+		t.Errorf("%%+v = %q, expected the internal message", got)
+	}
+}
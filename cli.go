@@ -0,0 +1,169 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Exit codes as defined by BSD sysexits.h
+const (
+	// ExitUsage is used when the command was used incorrectly, e.g. invalid arguments
+	ExitUsage = 64
+	// ExitDataErr is used when the input data was incorrect
+	ExitDataErr = 65
+	// ExitNoInput is used when an input file or resource did not exist
+	ExitNoInput = 66
+	// ExitUnavailable is used when a service is unavailable, or a feature is not supported
+	ExitUnavailable = 69
+	// ExitSoftware is used for internal software errors
+	ExitSoftware = 70
+	// ExitCantCreate is used when an output file or resource could not be created
+	ExitCantCreate = 73
+	// ExitTempFail is used for temporary failures, the user is invited to retry
+	ExitTempFail = 75
+	// ExitNoPerm is used when the user did not have sufficient permission
+	ExitNoPerm = 77
+	// ExitConfig is used for configuration errors
+	ExitConfig = 78
+	// ExitCancelled is not part of sysexits.h, it's the exit code used by shells for processes
+	// interrupted using SIGINT (Ctrl+C)
+	ExitCancelled = 130
+)
+
+// VerboseEnv is the environment variable which, if set to a true value (refer strconv.ParseBool),
+// makes Run print the stacktrace of the error
+const VerboseEnv = "ERRORS_VERBOSE"
+
+func exitCode(eT errType) int {
+	status := ExitSoftware
+	switch eT {
+	case TypeValidation:
+		{
+			status = ExitDataErr
+		}
+	case TypeInputBody:
+		{
+			status = ExitUsage
+		}
+	case TypeDuplicate:
+		{
+			status = ExitCantCreate
+		}
+	case TypeUnauthenticated, TypeUnauthorized:
+		{
+			status = ExitNoPerm
+		}
+	case TypeEmpty, TypeNotFound:
+		{
+			status = ExitNoInput
+		}
+	case TypeMaximumAttempts, TypeDownstreamDependencyTimedout, TypeContextTimedout:
+		{
+			status = ExitTempFail
+		}
+	case TypeNotImplemented, TypeSubscriptionExpired:
+		{
+			status = ExitUnavailable
+		}
+	case TypeContextCancelled:
+		{
+			status = ExitCancelled
+		}
+	}
+
+	return status
+}
+
+func (f *Factory) exitCode(et errType) int {
+	if f.config.ExitCode != nil {
+		if status := f.config.ExitCode(et); status != 0 {
+			return status
+		}
+	}
+	return exitCode(et)
+}
+
+// ExitCode returns the process exit code based on the type of the error, 0 if err is nil. If
+// there's no *Error, context errors are mapped to their respective types, and all others exit with 1.
+// In case of joined errors, it'll return the exit code of the last *Error
+//
+//	ExitDataErr:     TypeValidation
+//	ExitUsage:       TypeInputBody
+//	ExitCantCreate:  TypeDuplicate
+//	ExitNoPerm:      TypeUnauthenticated, TypeUnauthorized
+//	ExitNoInput:     TypeEmpty, TypeNotFound
+//	ExitTempFail:    TypeMaximumAttempts, TypeDownstreamDependencyTimedout, TypeContextTimedout
+//	ExitUnavailable: TypeNotImplemented, TypeSubscriptionExpired
+//	ExitCancelled:   TypeContextCancelled
+//	ExitSoftware:    all other types
+func ExitCode(err error) int {
+	return Default().ExitCode(err)
+}
+
+// ExitCode is same as the package level ExitCode, but uses the configuration of the factory
+func (f *Factory) ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	for inner := err; inner != nil; inner = Unwrap(inner) {
+		if derr, ok := asError(inner); ok {
+			return f.exitCode(derr.Type())
+		}
+
+		jerr, _ := inner.(*joinError)
+		if jerr == nil {
+			continue
+		}
+		for i := len(jerr.errs) - 1; i >= 0; i-- {
+			if Type(jerr.errs[i]).Int() != -1 {
+				return f.ExitCode(jerr.errs[i])
+			}
+		}
+	}
+
+	// Since TypeInternal is the default returned by errType, it is ignored.
+	if et := f.errType(err); et != TypeInternal {
+		return f.exitCode(et)
+	}
+
+	return 1
+}
+
+// Run is meant to be used in main of command line tools, e.g. `func main() { errors.Run(run) }`.
+// If fn returns an error, its user friendly message is printed to stderr, along with the stacktrace
+// if the environment variable VerboseEnv is set; and the process exits with the code returned by
+// ExitCode. If there's no user friendly message, the message of the root cause is printed if it's
+// not an *Error (e.g. a failed os.Open), since such errors do not have the file & line; and
+// DefaultMessage otherwise. Run returns normally if fn returns nil.
+func Run(fn func() error) {
+	if code := run(fn, os.Stderr, os.Getenv); code != 0 {
+		os.Exit(code)
+	}
+}
+
+func run(fn func() error, stderr io.Writer, getenv func(string) string) int {
+	err := fn()
+	if err == nil {
+		return 0
+	}
+
+	msg := userMessage(err, false)
+	if msg == "" {
+		msg = DefaultMessage
+		if cause := Cause(err); Find(cause, func(*Error) bool { return true }) == nil {
+			msg = cause.Error()
+		}
+	}
+	_, _ = fmt.Fprintf(stderr, "%s: %s\n", filepath.Base(os.Args[0]), strings.TrimSpace(msg))
+
+	if verbose, _ := strconv.ParseBool(getenv(VerboseEnv)); verbose {
+		_, _ = fmt.Fprintln(stderr, Stacktrace(err))
+	}
+
+	return ExitCode(err)
+}
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "internal", err: Internal("oops"), want: ExitSoftware},
+		{name: "validation", err: Validation("invalid"), want: ExitDataErr},
+		{name: "input body", err: InputBody("bad flag"), want: ExitUsage},
+		{name: "duplicate", err: Duplicate("exists"), want: ExitCantCreate},
+		{name: "unauthenticated", err: Unauthenticated("login"), want: ExitNoPerm},
+		{name: "unauthorized", err: Unauthorized("denied"), want: ExitNoPerm},
+		{name: "not found", err: NotFound("missing"), want: ExitNoInput},
+		{name: "empty", err: Empty("empty"), want: ExitNoInput},
+		{name: "maximum attempts", err: MaximumAttempts("retries"), want: ExitTempFail},
+		{name: "downstream timeout", err: DownstreamDependencyTimedout("slow"), want: ExitTempFail},
+		{name: "context timeout", err: ContextTimedout("slow"), want: ExitTempFail},
+		{name: "not implemented", err: NotImplemented("todo"), want: ExitUnavailable},
+		{name: "subscription expired", err: SubscriptionExpired("renew"), want: ExitUnavailable},
+		{name: "cancelled", err: ContextCancelled("ctrl+c"), want: ExitCancelled},
+		{name: "wrapped", err: fmt.Errorf("load: %w", NotFound("missing")), want: ExitNoInput},
+		{name: "std context", err: fmt.Errorf("load: %w", context.DeadlineExceeded), want: ExitTempFail},
+		{name: "foreign", err: errors.New("oops"), want: 1},
+		{name: "joined", err: Join(errors.New("oops"), InputBody("bad flag")), want: ExitUsage},
+		{name: "joined last", err: Join(NotFound("missing"), InputBody("bad flag"), errors.New("oops")), want: ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	f := NewFactory(Config{ExitCode: func(et errType) int {
+		if et == TypeNotFound {
+			return 2
+		}
+		return 0
+	}})
+	if got := f.ExitCode(NotFound("missing")); got != 2 {
+		t.Errorf("ExitCode() = %d, want 2", got)
+	}
+	if got := f.ExitCode(InputBody("bad flag")); got != ExitUsage {
+		t.Errorf("ExitCode() = %d, want %d", got, ExitUsage)
+	}
+}
+
+func TestRun(t *testing.T) {
+	fail := func() error {
+		return InputBodyErr(errors.New("unknown flag -x"), "invalid arguments")
+	}
+	env := func(value string) func(string) string {
+		return func(key string) string {
+			if key == VerboseEnv {
+				return value
+			}
+			return ""
+		}
+	}
+
+	stderr := bytes.NewBuffer(nil)
+	if code := run(func() error { return nil }, stderr, env("")); code != 0 || stderr.Len() != 0 {
+		t.Errorf("run() = %d, stderr %q", code, stderr.String())
+	}
+
+	stderr.Reset()
+	if code := run(fail, stderr, env("")); code != ExitUsage {
+		t.Errorf("run() = %d, want %d", code, ExitUsage)
+	}
+	if got := stderr.String(); !strings.HasSuffix(got, ": invalid arguments\n") || strings.Contains(got, "cli_test.go") {
+		t.Errorf("unexpected stderr %q", got)
+	}
+
+	stderr.Reset()
+	if code := run(fail, stderr, env("true")); code != ExitUsage {
+		t.Errorf("run() = %d, want %d", code, ExitUsage)
+	}
+	if got := stderr.String(); !strings.Contains(got, "cli_test.go") || !strings.Contains(got, "unknown flag -x") {
+		t.Errorf("stacktrace not printed in verbose mode %q", got)
+	}
+
+	stderr.Reset()
+	if code := run(func() error { return New("") }, stderr, env("")); code != ExitSoftware {
+		t.Errorf("run() = %d, want %d", code, ExitSoftware)
+	}
+	if got := stderr.String(); !strings.HasSuffix(got, ": "+DefaultMessage+"\n") || strings.Contains(got, "cli_test.go") {
+		t.Errorf("unexpected stderr %q", got)
+	}
+
+	stderr.Reset()
+	if code := run(func() error { return errors.New("plain") }, stderr, env("0")); code != 1 {
+		t.Errorf("run() = %d, want 1", code)
+	}
+	if got := stderr.String(); !strings.HasSuffix(got, ": plain\n") {
+		t.Errorf("unexpected stderr %q", got)
+	}
+
+	stderr.Reset()
+	notExist := func() error { return Wrap(errors.New("open config.yaml: no such file or directory")) }
+	if code := run(notExist, stderr, env("")); code != ExitSoftware {
+		t.Errorf("run() = %d, want %d", code, ExitSoftware)
+	}
+	if got := stderr.String(); !strings.HasSuffix(got, ": open config.yaml: no such file or directory\n") ||
+		strings.Contains(got, "cli_test.go") {
+		t.Errorf("unexpected stderr %q", got)
+	}
+}
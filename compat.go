@@ -0,0 +1,86 @@
+package errors
+
+import "reflect"
+
+// ForeignProgramCounters returns the program counters of an error which is not of type *Error, but
+// exposes its stacktrace using either of the following methods. It returns nil otherwise.
+//   - StackTrace() errors.StackTrace, as implemented by github.com/pkg/errors. Any slice of
+//     program counters is supported, i.e. of an element type whose underlying type is uintptr
+//   - Callers() []uintptr
+func ForeignProgramCounters(err error) []uintptr {
+	switch e := unembed(err).(type) {
+	case *Error:
+		return nil
+	case interface{ Callers() []uintptr }:
+		return e.Callers()
+	}
+	return stackTracePCs(err)
+}
+
+// stackTracePCs returns the program counters returned by the `StackTrace()` method of the error, if
+// it returns a slice of uintptr kind. It's detected structurally, so that this package does not
+// depend on the packages defining the stacktrace types (e.g. github.com/pkg/errors.StackTrace)
+func stackTracePCs(err error) []uintptr {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+
+	mtype := method.Type()
+	if mtype.NumIn() != 0 || mtype.NumOut() != 1 {
+		return nil
+	}
+	if out := mtype.Out(0); out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	st := method.Call(nil)[0]
+	pcs := make([]uintptr, 0, st.Len())
+	for idx := range st.Len() {
+		pcs = append(pcs, uintptr(st.Index(idx).Uint()))
+	}
+	return pcs
+}
+
+// Callers returns the program counters of the error's stacktrace. It is same as ProgramCounters,
+// and is provided for compatibility with packages which use the `Callers() []uintptr` interface
+func (e *Error) Callers() []uintptr {
+	return e.ProgramCounters()
+}
+
+// Cause returns the innermost error of the chain, i.e. the root cause. Joined errors are not
+// traversed.
+func Cause(err error) error {
+	for err != nil {
+		inner := Unwrap(err)
+		if inner == nil {
+			break
+		}
+		err = inner
+	}
+	return err
+}
+
+// Cause implements the causer interface of github.com/pkg/errors, so that its Cause function
+// traverses the wrapped errors of *Error. If e does not wrap any error, e is the root cause;
+// since pkg/errors considers errors not implementing causer as the root, e is returned wrapped
+// in one which does not implement it, and which unwraps to e
+func (e *Error) Cause() error {
+	if e.original != nil {
+		return e.original
+	}
+	return &rootCause{err: e}
+}
+
+// rootCause is returned by (*Error).Cause if the *Error does not wrap any error
+type rootCause struct {
+	err *Error
+}
+
+func (rc *rootCause) Error() string {
+	return rc.err.Error()
+}
+
+func (rc *rootCause) Unwrap() error {
+	return rc.err
+}
@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// stackFrame & stackTrace are the same as the types of github.com/pkg/errors
+type stackFrame uintptr
+
+type stackTrace []stackFrame
+
+type stackErr struct {
+	msg string
+	st  stackTrace
+}
+
+func (se *stackErr) Error() string {
+	return se.msg
+}
+
+func (se *stackErr) StackTrace() stackTrace {
+	return se.st
+}
+
+func pkgErrorsSite() error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(1, pcs)
+	st := make(stackTrace, 0, n)
+	for _, pc := range pcs[:n] {
+		st = append(st, stackFrame(pc))
+	}
+	return &stackErr{msg: "pkg error", st: st}
+}
+
+type callersErr struct {
+	pcs []uintptr
+}
+
+func (ce *callersErr) Error() string {
+	return "callers error"
+}
+
+func (ce *callersErr) Callers() []uintptr {
+	return ce.pcs
+}
+
+func TestForeignStacktrace(t *testing.T) {
+	err := Wrap(pkgErrorsSite(), "wrapped pkg error")
+
+	pcs := ForeignProgramCounters(Unwrap(err))
+	if len(pcs) == 0 {
+		t.Fatal("ForeignProgramCounters() should return the pkg/errors stacktrace")
+	}
+	if ForeignProgramCounters(err) != nil || ForeignProgramCounters(errors.New("std")) != nil {
+		t.Error("ForeignProgramCounters() should be nil for *Error & errors without stacktrace")
+	}
+
+	if got := ProgramCounters(err); !slices.Contains(got, pcs[0]) {
+		t.Errorf("ProgramCounters() = %v, should include %v", got, pcs[0])
+	}
+
+	frames := Frames(err)
+	var foreign *Frame
+	for idx := range frames {
+		if frames[idx].Function == "github.com/naughtygopher/errors.pkgErrorsSite" {
+			foreign = &frames[idx]
+		}
+	}
+	if foreign == nil || foreign.Err() != nil || foreign.Message != "pkg error" {
+		t.Errorf("Frames() should include the pkg/errors frames: %+v", frames)
+	}
+
+	got := Stacktrace(err)
+	if !strings.Contains(got, "errors.TestForeignStacktrace(): wrapped pkg error") ||
+		!strings.Contains(got, "errors.pkgErrorsSite(): pkg error") {
+		t.Errorf("Stacktrace() = %s", got)
+	}
+
+	ce := &callersErr{pcs: New("callers").ProgramCounters()}
+	if got := ForeignProgramCounters(ce); len(got) == 0 {
+		t.Error("ForeignProgramCounters() should support Callers()")
+	}
+	if got := New("callers").Callers(); len(got) == 0 {
+		t.Error("Callers() should return the program counters")
+	}
+}
+
+func TestForeignStacktraceFormats(t *testing.T) {
+	err := Wrap(pkgErrorsSite(), "wrapped pkg error")
+
+	lines := StacktraceNoFormat(err)
+	if !slices.Contains(lines, "github.com/naughtygopher/errors.pkgErrorsSite(): pkg error") {
+		t.Errorf("StacktraceNoFormat() = %q", lines)
+	}
+
+	got := StacktraceCustomFormat("%m|%f\n", "\t%s\n", err)
+	if !strings.Contains(got, "pkg error|github.com/naughtygopher/errors.pkgErrorsSite\n\tcompat_test.go\n") {
+		t.Errorf("StacktraceCustomFormat() = %q", got)
+	}
+
+	got = StacktraceCustomFormat("%m|%f|%l|%t\n", "", Wrap(errors.New("std error"), "wrapped"))
+	if !strings.HasSuffix(got, "std error|||\n") {
+		t.Errorf("StacktraceCustomFormat() = %q", got)
+	}
+
+	if got := stackTracePCs(&struct{ error }{errors.New("no stacktrace")}); got != nil {
+		t.Errorf("stackTracePCs() = %v, want nil", got)
+	}
+}
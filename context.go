@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ContextExtractor returns the attributes to be attached to an error, extracted from the context.
+// e.g. request ID, tenant ID, trace ID etc.
+type ContextExtractor func(ctx context.Context) []Attr
+
+type extractorEntry struct {
+	fn ContextExtractor
+}
+
+var (
+	extractorsMu = sync.Mutex{}
+	extractors   = atomic.Pointer[[]*extractorEntry]{}
+)
+
+// RegisterContextExtractor registers an extractor which is used by all the *Ctx functions
+// (NewCtx, WrapCtx etc.) to attach attributes from the context to the error, and returns a
+// function to unregister it
+func RegisterContextExtractor(fn ContextExtractor) (unregister func()) {
+	entry := &extractorEntry{fn: fn}
+
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	list := []*extractorEntry{}
+	if existing := extractors.Load(); existing != nil {
+		list = append(list, *existing...)
+	}
+	list = append(list, entry)
+	extractors.Store(&list)
+
+	return func() {
+		extractorsMu.Lock()
+		defer extractorsMu.Unlock()
+		existing := extractors.Load()
+		if existing == nil {
+			return
+		}
+		list := make([]*extractorEntry, 0, len(*existing))
+		for _, e := range *existing {
+			if e != entry {
+				list = append(list, e)
+			}
+		}
+		if len(list) == 0 {
+			extractors.Store(nil)
+			return
+		}
+		extractors.Store(&list)
+	}
+}
+
+// ContextValue returns an extractor which attaches the value stored in the context against key,
+// as an attribute with the given name. Nil values are ignored
+func ContextValue(key any, name string) ContextExtractor {
+	return func(ctx context.Context) []Attr {
+		value := ctx.Value(key)
+		if value == nil {
+			return nil
+		}
+		return []Attr{Attribute(name, value)}
+	}
+}
+
+func contextAttrs(ctx context.Context) []Attr {
+	list := extractors.Load()
+	if ctx == nil || list == nil {
+		return nil
+	}
+
+	attrs := make([]Attr, 0, len(*list))
+	for _, entry := range *list {
+		attrs = append(attrs, entry.fn(ctx)...)
+	}
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	return attrs
+}
+
+// buildCtx returns a Builder with the attributes extracted from the context
+func buildCtx(ctx context.Context) Builder {
+	return Build().Attrs(contextAttrs(ctx)...)
+}
+
+// NewCtx is same as New, and attaches attributes extracted from the context using the registered
+// extractors
+func NewCtx(ctx context.Context, msg string) *Error {
+	return buildCtx(ctx).newerr(nil, msg, 3)
+}
+
+// NewCtxf is same as Newf, and attaches attributes extracted from the context using the registered
+// extractors
+func NewCtxf(ctx context.Context, format string, args ...any) *Error {
+	return buildCtx(ctx).newerrf(nil, 4, format, args...)
+}
+
+// NewWithTypeCtx is same as NewWithType, and attaches attributes extracted from the context using
+// the registered extractors
+func NewWithTypeCtx(ctx context.Context, msg string, etype errType) *Error {
+	return buildCtx(ctx).Type(etype).newerr(nil, msg, 3)
+}
+
+// WrapCtx is same as Wrap, and attaches attributes extracted from the context using the registered
+// extractors
+func WrapCtx(ctx context.Context, original error, msg ...string) *Error {
+	return buildCtx(ctx).newerr(original, strings.Join(msg, ". "), 3)
+}
+
+// WrapCtxf is same as Wrapf, and attaches attributes extracted from the context using the registered
+// extractors
+func WrapCtxf(ctx context.Context, original error, format string, args ...any) *Error {
+	return buildCtx(ctx).newerrf(original, 4, format, args...)
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type ctxKey string
+
+func TestWrapCtx(t *testing.T) {
+	t.Cleanup(RegisterContextExtractor(ContextValue(ctxKey("request_id"), "request_id")))
+	t.Cleanup(RegisterContextExtractor(ContextValue(ctxKey("tenant_id"), "tenant_id")))
+
+	ctx := context.WithValue(context.Background(), ctxKey("request_id"), "req-1")
+	err := WrapCtx(ctx, errors.New("original error"), "wrapped error")
+	want := []Attr{Attribute("request_id", "req-1")}
+	if !reflect.DeepEqual(err.Attributes(), want) {
+		t.Errorf("Attributes() = %v, want %v", err.Attributes(), want)
+	}
+	if err.Type() != TypeInternal {
+		t.Errorf("Type() = %v, want %v", err.Type(), TypeInternal)
+	}
+
+	ctx = context.WithValue(ctx, ctxKey("tenant_id"), "tenant-1")
+	err = WrapCtxf(ctx, NotFound("user not found"), "user %d", 1)
+	if err.Type() != TypeNotFound {
+		t.Errorf("Type() = %v, want %v", err.Type(), TypeNotFound)
+	}
+	want = []Attr{Attribute("request_id", "req-1"), Attribute("tenant_id", "tenant-1")}
+	if !reflect.DeepEqual(err.Attributes(), want) {
+		t.Errorf("Attributes() = %v, want %v", err.Attributes(), want)
+	}
+
+	err = NewCtx(context.Background(), "no values")
+	if err.Attributes() != nil {
+		t.Errorf("Attributes() = %v, want nil", err.Attributes())
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	inner := NewWithTypeCtx(context.Background(), "inner", TypeValidation)
+	inner.attrs = []Attr{Attribute("a", 1), Attribute("b", 2)}
+	outer := Wrap(inner, "outer")
+	outer.attrs = []Attr{Attribute("b", 3)}
+
+	joined := Join(outer, &Error{attrs: []Attr{Attribute("c", 4)}})
+	got := Attributes(joined)
+	want := []Attr{Attribute("b", 3), Attribute("a", 1), Attribute("c", 4)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Attributes() = %v, want %v", got, want)
+	}
+
+	if got := Attributes(errors.New("std")); got != nil {
+		t.Errorf("Attributes() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,43 @@
+package errors
+
+// The With* methods return a copy of the error with the respective field changed. The original
+// error is never modified, so they're safe to use on errors shared across goroutines. The copy
+// shares the program counters of the original, i.e. it has the same stacktrace & location.
+
+func (e *Error) clone() *Error {
+	derived := *e
+	return &derived
+}
+
+// WithType returns a copy of the error with the given type
+func (e *Error) WithType(et errType) *Error {
+	derived := e.clone()
+	derived.eType = et
+	return derived
+}
+
+// WithMessage returns a copy of the error with the given message. Since the message is replaced,
+// the format string, unredacted message & localization key of the original are not retained
+func (e *Error) WithMessage(msg string) *Error {
+	derived := e.clone()
+	derived.message = msg
+	derived.format = ""
+	derived.args = nil
+	derived.msgKey = ""
+	derived.msgArgs = nil
+	return derived
+}
+
+// WithCode returns a copy of the error with the given code
+func (e *Error) WithCode(code string) *Error {
+	derived := e.clone()
+	derived.code = code
+	return derived
+}
+
+// WithAttrs returns a copy of the error with the given attributes added to the existing ones
+func (e *Error) WithAttrs(attrs ...Attr) *Error {
+	derived := e.clone()
+	derived.attrs = append(e.attrs[:len(e.attrs):len(e.attrs)], attrs...)
+	return derived
+}
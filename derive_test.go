@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithMethods(t *testing.T) {
+	original := Wrapf(errors.New("i/o timeout"), "call %s", "billing")
+	original.attrs = []Attr{Attribute("service", "billing")}
+	location := original.fileLine()
+
+	typed := original.WithType(TypeDownstreamDependencyTimedout)
+	coded := original.WithCode("billing_timeout")
+	messaged := original.WithMessage("billing is unavailable")
+	attributed := original.WithAttrs(Attribute("attempt", 3))
+
+	if original.Type() != TypeInternal || original.Code() != "" || original.message != "call billing" ||
+		len(original.attrs) != 1 || original.format != "call %s" {
+		t.Errorf("original error was modified: %#v", original)
+	}
+
+	if typed.Type() != TypeDownstreamDependencyTimedout {
+		t.Errorf("WithType().Type() = %v", typed.Type())
+	}
+	if coded.Code() != "billing_timeout" {
+		t.Errorf("WithCode().Code() = %q", coded.Code())
+	}
+	if messaged.Message() != "billing is unavailable" || messaged.format != "" {
+		t.Errorf("WithMessage() = %q, format %q", messaged.Message(), messaged.format)
+	}
+	if attrs := attributed.Attributes(); len(attrs) != 2 || attrs[1].Key != "attempt" {
+		t.Errorf("WithAttrs().Attributes() = %v", attrs)
+	}
+
+	for _, derived := range []*Error{typed, coded, messaged, attributed} {
+		if derived == original {
+			t.Fatal("With* should return a new error")
+		}
+		if derived.fileLine() != location || &derived.pcs[0] != &original.pcs[0] {
+			t.Errorf("derived error should share the program counters of the original")
+		}
+		if !errors.Is(derived, original.Unwrap()) || derived.Unwrap() != original.Unwrap() {
+			t.Errorf("derived error should wrap the same error")
+		}
+		if !strings.Contains(derived.Error(), "i/o timeout") {
+			t.Errorf("Error() = %q", derived.Error())
+		}
+	}
+}
+
+func TestWithAttrsConcurrent(t *testing.T) {
+	original := New("shared")
+	original.attrs = make([]Attr, 1, 8)
+	original.attrs[0] = Attribute("a", 1)
+
+	wg := sync.WaitGroup{}
+	results := make([]*Error, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = original.WithAttrs(Attribute("i", i))
+		}()
+	}
+	wg.Wait()
+
+	for i, derived := range results {
+		attrs := derived.Attributes()
+		if len(attrs) != 2 || attrs[1].Value != i {
+			t.Errorf("WithAttrs() = %v, want index %d", attrs, i)
+		}
+	}
+	if len(original.attrs) != 1 {
+		t.Errorf("original attributes were modified: %v", original.attrs)
+	}
+}
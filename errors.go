@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type errType int
@@ -16,8 +17,44 @@ func (e errType) Int() int {
 	return int(e)
 }
 
+// String returns the name of the error type, e.g. "NotFound". Unknown types are printed
+// as "errType(N)"
+func (e errType) String() string {
+	name, ok := typeName(e)
+	if !ok {
+		return "errType(" + strconv.Itoa(int(e)) + ")"
+	}
+	return name
+}
+
+// MarshalText implements encoding.TextMarshaler, so that the error type is serialized using
+// its name instead of its integer value. Unknown types are serialized as "errType(N)", so that
+// serializing an error never fails
+func (e errType) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any name supported by ParseType,
+// as well as "errType(N)" of unknown types
+func (e *errType) UnmarshalText(text []byte) error {
+	et, err := ParseType(string(text))
+	if err == nil {
+		*e = et
+		return nil
+	}
+
+	num, ok := strings.CutPrefix(string(text), "errType(")
+	if num, ok = strings.CutSuffix(num, ")"); ok {
+		if n, nerr := strconv.Atoi(num); nerr == nil {
+			*e = errType(n)
+			return nil
+		}
+	}
+	return err
+}
+
 // While adding a new Type, the respective helper functions should be added, also update the
-// WriteHTTP method accordingly
+// WriteHTTP method accordingly, and add its name in typeNames (types.go)
 const (
 	// TypeInternal is error type for when there is an internal system error. e.g. Database errors
 	TypeInternal errType = iota
@@ -55,10 +92,6 @@ const (
 	DefaultMessage = "unknown error occurred"
 )
 
-var (
-	defaultErrType = TypeInternal
-)
-
 // Error is the struct which holds custom attributes
 type Error struct {
 	// original is the original error
@@ -69,9 +102,34 @@ type Error struct {
 	eType errType
 	pcs   []uintptr
 	pc    uintptr
+	// attrs are the key-value pairs attached to the error, e.g. request ID
+	attrs []Attr
+	// code is an optional application specific code of the error
+	code string
+	// format is the format string used to create the message, if any
+	format string
+	// args are the arguments of the formatted message, retained only if any of them is Sensitive
+	// so that the message is redacted as per the policy when rendered
+	args []any
+	// msgKey is the key of the localized message, refer NewLocalized
+	msgKey  string
+	msgArgs []any
+	// payload is the domain data carried by the error, refer PayloadError
+	payload any
+	// at is the time when the error was created, refer Config.Clock
+	at time.Time
+	// internal is the message meant only for developers, refer Builder.InternalMessage
+	internal   string
+	severity   Severity
+	retryable  bool
+	retryAfter time.Duration
 }
 
 func (e *Error) fileLine() string {
+	return e.fileLineWith(currentTraceOptions())
+}
+
+func (e *Error) fileLineWith(opts TraceOptions) string {
 	if e.pc == 0 {
 		return ""
 	}
@@ -80,30 +138,62 @@ func (e *Error) fileLine() string {
 	frame, _ := frames.Next()
 
 	buff := bytes.NewBuffer(make([]byte, 0, 128))
-	buff.WriteString(frame.File)
+	buff.WriteString(opts.path(frame.Function, frame.File))
 	buff.WriteString(":")
 	buff.WriteString(strconv.Itoa(frame.Line))
 
 	return buff.String()
 }
 
+// fileFunction returns the function where the error was created
+func (e *Error) fileFunction() string {
+	if e.pc == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames([]uintptr{e.pc + 1})
+	frame, _ := frames.Next()
+	return frame.Function
+}
+
 // Error is the implementation of error interface
 func (e *Error) Error() string {
+	return e.errorString(false)
+}
+
+// errorString returns the file:line & message of the error followed by that of the wrapped ones.
+// If verbose is true, the creation time of each *Error is prefixed to its message and the internal
+// message is appended to it, refer Builder.InternalMessage
+func (e *Error) errorString(verbose bool) string {
 	str := bytes.NewBuffer(make([]byte, 0, 128))
 	str.WriteString(e.fileLine())
 	if str.Len() != 0 {
 		str.WriteString(": ")
 	}
+	if at := e.formattedTime(); verbose && at != "" {
+		str.WriteString("[")
+		str.WriteString(at)
+		str.WriteString("] ")
+	}
+
+	message := e.text()
+	if verbose {
+		message = e.verboseMessage()
+	}
 
 	if e.original != nil {
-		str.WriteString(e.message)
+		str.WriteString(message)
 		str.WriteString("\n")
-		str.WriteString(e.original.Error())
+		if o, ok := asError(e.original); ok && verbose {
+			str.WriteString(o.errorString(verbose))
+		} else {
+			str.WriteString(e.original.Error())
+		}
 		return str.String()
 	}
 
-	if e.message != "" {
-		str.WriteString(e.message)
+	if message != "" {
+		str.WriteString(message)
 		return str.String()
 	}
 
@@ -112,14 +202,28 @@ func (e *Error) Error() string {
 	return str.String()
 }
 
+// verboseMessage returns the message along with the internal message, if any. It's used only by
+// the verbose formats ('%+v' & stacktraces), the internal message is never a part of Error(),
+// Message() or the serializers
+func (e *Error) verboseMessage() string {
+	message := e.text()
+	switch {
+	case e.internal == "":
+		return message
+	case message == "":
+		return e.internal
+	}
+	return message + ": " + e.internal
+}
+
 // ErrorWithoutFileLine prints the final string without the stack trace / file+line number
 func (e *Error) ErrorWithoutFileLine() string {
 	if e.original != nil {
-		if e.message != "" {
+		if message := e.text(); message != "" {
 			msg := bytes.NewBuffer(make([]byte, 0, 128))
-			msg.WriteString(e.message)
+			msg.WriteString(message)
 			msg.WriteString(": ")
-			if o, ok := e.original.(*Error); ok {
+			if o, ok := asError(e.original); ok {
 				msg.WriteString(o.ErrorWithoutFileLine())
 			} else {
 				msg.WriteString(e.original.Error())
@@ -129,8 +233,8 @@ func (e *Error) ErrorWithoutFileLine() string {
 		return e.original.Error()
 	}
 
-	if e.message != "" {
-		return e.message
+	if message := e.text(); message != "" {
+		return message
 	}
 
 	return e.fileLine()
@@ -140,18 +244,19 @@ func (e *Error) ErrorWithoutFileLine() string {
 // which are not of type *Error
 func (e *Error) Message() string {
 	messages := make([]string, 0, 5)
-	if e.message != "" {
-		messages = append(messages, e.message)
+	if message := e.text(); message != "" {
+		messages = append(messages, message)
 	}
 
-	err, _ := e.original.(*Error)
+	err, _ := asError(e.original)
 	for err != nil {
-		if err.message == "" {
-			err, _ = err.original.(*Error)
+		message := err.text()
+		if message == "" {
+			err, _ = asError(err.original)
 			continue
 		}
-		messages = append(messages, err.message)
-		err, _ = err.original.(*Error)
+		messages = append(messages, message)
+		err, _ = asError(err.original)
 	}
 
 	if len(messages) > 0 {
@@ -166,9 +271,40 @@ func (e *Error) Unwrap() error {
 	return e.original
 }
 
-// Is implements the Is interface required by Go
+// embedder is implemented by types which embed *Error, e.g. PayloadError
+type embedder interface {
+	base() *Error
+}
+
+// asError returns the *Error of err, if err is an *Error or a type embedding *Error
+func asError(err error) (*Error, bool) {
+	switch e := err.(type) {
+	case *Error:
+		return e, true
+	case embedder:
+		b := e.base()
+		return b, b != nil
+	}
+	return nil, false
+}
+
+// unembed returns the embedded *Error if err embeds one, and err as is otherwise
+func unembed(err error) error {
+	if e, ok := err.(embedder); ok {
+		if b := e.base(); b != nil {
+			return b
+		}
+	}
+	return err
+}
+
+// Is implements the Is interface required by Go. An *Error is only equal to itself, except for
+// sentinels (e.g. ErrNotFound, CodeSentinel) which match all errors of their type or code
 func (e *Error) Is(err error) bool {
-	o, _ := err.(*Error)
+	if s, ok := err.(*sentinel); ok {
+		return s.matches(e)
+	}
+	o, _ := asError(err)
 	return o == e
 }
 
@@ -176,7 +312,7 @@ func (e *Error) Is(err error) bool {
 // HTTP response status code for the respective error type.
 // deprecated to free the Error type from protocol specific features
 func (e *Error) HTTPStatusCode() int {
-	return httpStatusCode(e.eType)
+	return Default().httpStatusCode(e.eType)
 }
 
 // Type returns the error type as integer
@@ -184,12 +320,17 @@ func (e *Error) Type() errType {
 	return e.eType
 }
 
+// Code returns the application specific code of the error, if set
+func (e *Error) Code() string {
+	return e.code
+}
+
 // Format implements the verbs/directives supported by Error to be used in fmt annotated/formatted strings
 /*
 %v  - the same output as Message(). i.e. recursively get all the custom messages set by user
     - if any of the wrapped error is not of type *Error, that will *not* be displayed
-%+v - recursively prints all the messages along with the file & line number. Also includes output of `Error()` of
-non *Error types.
+%+v - recursively prints all the messages along with the file & line number, and the creation time if recorded.
+Also includes output of `Error()` of non *Error types.
 
 %s  - identical to %v
 %+s - recursively prints all the messages without file & line number. Also includes output `Error()` of
@@ -199,7 +340,7 @@ func (e *Error) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			_, _ = io.WriteString(s, e.Error())
+			_, _ = io.WriteString(s, e.errorString(true))
 		} else {
 			_, _ = io.WriteString(s, e.Message())
 		}
@@ -223,46 +364,104 @@ func (e *Error) ProgramCounters() []uintptr {
 }
 
 func (e *Error) StackTrace() []string {
-	rframes := e.RuntimeFrames()
-	frame, ok := rframes.Next()
+	return e.stackTrace(currentTraceOptions())
+}
+
+// traceFrames returns the frames used by the string representations of the stacktrace, i.e. all
+// the frames except runtime.goexit, the entry point of every goroutine
+func traceFrames(frames []Frame) []Frame {
+	if last := len(frames) - 1; last >= 0 && frames[last].Function == "runtime.goexit" {
+		return frames[:last]
+	}
+	return frames
+}
+
+func (e *Error) stackTrace(opts TraceOptions) []string {
+	return traceLineTexts(e.traceLines(opts))
+}
+
+// traceLine is a line of the stacktrace printed by Stacktrace, frame is set for the lines of
+// frames, except for collapsed ones
+type traceLine struct {
+	text  string
+	frame *Frame
+}
+
+func traceLineTexts(lines []traceLine) []string {
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		texts = append(texts, line.text)
+	}
+	return texts
+}
+
+func (e *Error) traceLines(opts TraceOptions) []traceLine {
+	lines := stackTraceLines(e.Frames(), e.verboseMessage(), opts)
+	if at := e.formattedTime(); at != "" {
+		lines[0].text += " [" + at + "]"
+	}
+	return lines
+}
+
+func stackTraceLines(frames []Frame, message string, opts TraceOptions) []traceLine {
+	first := Frame{}
+	if len(frames) > 0 {
+		first = frames[0]
+	}
+
 	buff := bytes.NewBuffer(make([]byte, 0, 128))
-	buff.WriteString(frame.Function)
+	buff.WriteString(first.Function)
 	buff.WriteString("(): ")
-	buff.WriteString(e.message)
+	buff.WriteString(message)
 
-	trace := make([]string, 0, len(e.ProgramCounters()))
-	trace = append(trace, buff.String())
-	for ok {
+	filtered := opts.filter(traceFrames(frames))
+	trace := make([]traceLine, 0, len(filtered)+1)
+	trace = append(trace, traceLine{text: buff.String()})
+	for _, tf := range filtered {
 		buff.Reset()
 		buff.WriteString("\t")
-		buff.WriteString(frame.File)
+		if tf.collapsed > 0 {
+			buff.WriteString(tf.collapsedLine())
+			trace = append(trace, traceLine{text: buff.String()})
+			continue
+		}
+		buff.WriteString(opts.path(tf.Function, tf.File))
 		buff.WriteString(":")
-		buff.WriteString(strconv.Itoa(frame.Line))
-		trace = append(trace, buff.String())
-		frame, ok = rframes.Next()
+		buff.WriteString(strconv.Itoa(tf.Line))
+		trace = append(trace, traceLine{text: buff.String(), frame: &tf.Frame})
 	}
 	return trace
 }
 
 func (e *Error) StackTraceNoFormat() []string {
-	rframes := e.RuntimeFrames()
-	frame, ok := rframes.Next()
-	line := strconv.Itoa(frame.Line)
+	return noFormatLines(e.Frames(), e.text())
+}
+
+func noFormatLines(frames []Frame, message string) []string {
+	first := Frame{}
+	if len(frames) > 0 {
+		first = frames[0]
+	}
 
 	buff := bytes.NewBuffer(make([]byte, 0, 128))
-	buff.WriteString(frame.Function)
+	buff.WriteString(first.Function)
 	buff.WriteString("(): ")
-	buff.WriteString(e.message)
+	buff.WriteString(message)
 
-	trace := make([]string, 0, len(e.ProgramCounters()))
+	opts := currentTraceOptions()
+	filtered := opts.filter(traceFrames(frames))
+	trace := make([]string, 0, len(filtered)+1)
 	trace = append(trace, buff.String())
-	for ok {
+	for _, tf := range filtered {
 		buff.Reset()
-		buff.WriteString(frame.File)
-		buff.WriteString(":")
-		buff.WriteString(line)
+		if tf.collapsed > 0 {
+			buff.WriteString(tf.collapsedLine())
+		} else {
+			buff.WriteString(opts.path(tf.Function, tf.File))
+			buff.WriteString(":")
+			buff.WriteString(strconv.Itoa(tf.Line))
+		}
 		trace = append(trace, buff.String())
-		frame, ok = rframes.Next()
 	}
 	return trace
 }
@@ -274,26 +473,45 @@ Supported directives:
 %p - file path
 %l - line
 %f - function
+%k - package
+%s - file name, without the directory
+%x - program counter, in hexadecimal
+%t - error type name
+%c - code
+%a - attributes, as space separated key=value pairs
+%% - a literal '%', e.g. "%%m" is printed as "%m"
+
+Any other '%' is printed as is. Frames are dropped & collapsed as per the
+TraceOptions; for collapsed frames, %f is "... N frame(s) in <prefix>" and the other frame
+directives are empty.
 */
 func (e *Error) StackTraceCustomFormat(msgformat string, traceFormat string) []string {
-	rframes := e.RuntimeFrames()
-	frame, ok := rframes.Next()
+	return e.stackTraceCustomFormat(parseStackTemplate(msgformat), parseStackTemplate(traceFormat))
+}
 
-	message := strings.ReplaceAll(msgformat, "%m", e.message)
-	message = strings.ReplaceAll(message, "%p", frame.File)
-	message = strings.ReplaceAll(message, "%l", strconv.Itoa(frame.Line))
-	message = strings.ReplaceAll(message, "%f", frame.Function)
-	traces := make([]string, 0, len(e.ProgramCounters()))
-	traces = append(traces, message)
+func (e *Error) stackTraceCustomFormat(msgTemplate stackTemplate, traceTemplate stackTemplate) []string {
+	return customFormatLines(e.Frames(), e, e, msgTemplate, traceTemplate)
+}
+
+// customFormatLines returns the stacktrace lines of the frames using the templates. e is nil for
+// errors which are not of type *Error
+func customFormatLines(frames []Frame, e *Error, err error, msgTemplate stackTemplate, traceTemplate stackTemplate) []string {
+	first := Frame{}
+	if len(frames) > 0 {
+		first = frames[0]
+	}
+	opts := currentTraceOptions()
 
-	for ok {
-		trace := strings.ReplaceAll(traceFormat, "%m", e.message)
-		trace = strings.ReplaceAll(trace, "%p", frame.File)
-		trace = strings.ReplaceAll(trace, "%l", strconv.Itoa(frame.Line))
-		trace = strings.ReplaceAll(trace, "%f", frame.Function)
+	buff := bytes.NewBuffer(make([]byte, 0, 128))
+	msgTemplate.render(buff, opts, e, err, traceFrame{Frame: first})
 
-		traces = append(traces, trace)
-		frame, ok = rframes.Next()
+	filtered := opts.filter(traceFrames(frames))
+	traces := make([]string, 0, len(filtered)+1)
+	traces = append(traces, buff.String())
+	for _, tf := range filtered {
+		buff.Reset()
+		traceTemplate.render(buff, opts, e, err, tf)
+		traces = append(traces, buff.String())
 	}
 
 	return traces
@@ -301,34 +519,49 @@ func (e *Error) StackTraceCustomFormat(msgformat string, traceFormat string) []s
 
 // New returns a new instance of Error with the relavant fields initialized
 func New(msg string) *Error {
-	return newerr(nil, msg, defaultErrType, 3)
+	return Build().newerr(nil, msg, 3)
 }
 
 func Newf(fromat string, args ...any) *Error {
-	return newerrf(nil, defaultErrType, 4, fromat, args...)
+	return Build().newerrf(nil, 4, fromat, args...)
 }
 
 // Errorf is a convenience method to create a new instance of Error with formatted message
 // Important: %w directive is not supported, use fmt.Errorf if you're using the %w directive or
 // use Wrap/Wrapf to wrap an error.
 func Errorf(fromat string, args ...any) *Error {
-	return newerrf(nil, defaultErrType, 4, fromat, args...)
+	return Build().newerrf(nil, 4, fromat, args...)
 }
 
-// SetDefaultType will set the default error type, which is used in the 'New' function
+// SetDefaultType will set the default error type of the default factory, which is used in the 'New' function
 func SetDefaultType(e errType) {
-	defaultErrType = e
+	updateDefault(func(config *Config) {
+		config.DefaultType = e
+	})
 }
 
 // Stacktrace returns a string representation of the stacktrace, where each trace is separated by a newline and tab '\t'
 func Stacktrace(err error) string {
-	trace := make([][]string, 0, 128)
+	return StacktraceWithOptions(err, currentTraceOptions())
+}
+
+// StacktraceWithOptions is same as Stacktrace, but uses the provided options instead of the global ones
+func StacktraceWithOptions(err error, opts TraceOptions) string {
+	return strings.Join(traceLineTexts(chainTraceLines(err, opts)), "\n")
+}
+
+// chainTraceLines returns the lines of the stacktrace of all the errors in the chain, where the
+// lines repeated from the stacktraces of the wrapped errors are dropped
+func chainTraceLines(err error, opts TraceOptions) []traceLine {
+	trace := make([][]traceLine, 0, 128)
 	for err != nil {
-		e, ok := err.(*Error)
+		e, ok := asError(err)
 		if ok {
-			trace = append(trace, e.StackTrace())
+			trace = append(trace, e.traceLines(opts))
+		} else if pcs := ForeignProgramCounters(err); pcs != nil {
+			trace = append(trace, stackTraceLines(pcFrames(pcs, err.Error(), nil), err.Error(), opts))
 		} else {
-			trace = append(trace, []string{err.Error()})
+			trace = append(trace, []traceLine{{text: err.Error()}})
 		}
 		err = Unwrap(err)
 	}
@@ -336,23 +569,23 @@ func Stacktrace(err error) string {
 	lookup := map[string]struct{}{}
 	for idx := len(trace) - 1; idx >= 0; idx-- {
 		list := trace[idx]
-		uniqueList := make([]string, 0, len(list))
+		uniqueList := make([]traceLine, 0, len(list))
 		for _, line := range list {
-			_, ok := lookup[line]
+			_, ok := lookup[line.text]
 			if ok {
 				break
 			}
 			uniqueList = append(uniqueList, line)
-			lookup[line] = struct{}{}
+			lookup[line.text] = struct{}{}
 		}
 		trace[idx] = uniqueList
 	}
-	final := make([]string, 0, len(trace)*3)
+	final := make([]traceLine, 0, len(trace)*3)
 	for _, list := range trace {
 		final = append(final, list...)
 	}
 
-	return strings.Join(final, "\n")
+	return final
 }
 
 // Stacktrace returns a string representation of the stacktrace, as a slice of string where each
@@ -360,9 +593,11 @@ func Stacktrace(err error) string {
 func StacktraceNoFormat(err error) []string {
 	trace := make([][]string, 0, 128)
 	for err != nil {
-		e, ok := err.(*Error)
+		e, ok := asError(err)
 		if ok {
 			trace = append(trace, e.StackTraceNoFormat())
+		} else if pcs := ForeignProgramCounters(err); pcs != nil {
+			trace = append(trace, noFormatLines(pcFrames(pcs, err.Error(), nil), err.Error()))
 		} else {
 			trace = append(trace, []string{err.Error()})
 		}
@@ -395,24 +630,26 @@ func StacktraceNoFormat(err error) []string {
 /*
 msgformat - is used to format the line which prints message from Error.message
 traceFormat - is used to format the line which prints trace
-Supported directives:
-%m - message if err type is *Error, otherwise output of `.Error()`
-%p - file path, empty if type is not *Error
-%l - line, empty if type is not *Error
-%f - function, empty if type is not *Error
+Supported directives are the same as of (*Error).StackTraceCustomFormat. For errors which are
+not of type *Error, %m is the output of `.Error()`, the frame directives are available only if
+the error exposes its stacktrace (refer ForeignProgramCounters) and all other directives are empty.
 */
 func StacktraceCustomFormat(msgformat string, traceFormat string, err error) string {
+	msgTemplate := parseStackTemplate(msgformat)
+	traceTemplate := parseStackTemplate(traceFormat)
+
 	trace := make([][]string, 0, 128)
 	for err != nil {
-		e, ok := err.(*Error)
+		e, ok := asError(err)
 		if ok {
-			trace = append(trace, e.StackTraceCustomFormat(msgformat, traceFormat))
+			trace = append(trace, e.stackTraceCustomFormat(msgTemplate, traceTemplate))
+		} else if pcs := ForeignProgramCounters(err); pcs != nil {
+			frames := pcFrames(pcs, err.Error(), nil)
+			trace = append(trace, customFormatLines(frames, nil, err, msgTemplate, traceTemplate))
 		} else {
-			message := strings.ReplaceAll(msgformat, "%m", err.Error())
-			message = strings.ReplaceAll(message, "%p", "")
-			message = strings.ReplaceAll(message, "%l", "")
-			message = strings.ReplaceAll(message, "%f", "")
-			trace = append(trace, []string{message})
+			buff := bytes.NewBuffer(make([]byte, 0, 128))
+			msgTemplate.render(buff, TraceOptions{}, nil, err, traceFrame{})
+			trace = append(trace, []string{buff.String()})
 		}
 		err = Unwrap(err)
 	}
@@ -443,27 +680,16 @@ func StacktraceCustomFormat(msgformat string, traceFormat string, err error) str
 func ProgramCounters(err error) []uintptr {
 	pcs := make([][]uintptr, 0, 128)
 	for err != nil {
-		e, ok := err.(*Error)
+		e, ok := asError(err)
 		if ok {
 			pcs = append(pcs, e.ProgramCounters())
+		} else if fpcs := ForeignProgramCounters(err); fpcs != nil {
+			pcs = append(pcs, fpcs)
 		}
 		err = Unwrap(err)
 	}
 
-	lookup := map[uintptr]struct{}{}
-	for idx := len(pcs) - 1; idx >= 0; idx-- {
-		list := pcs[idx]
-		uniqueList := make([]uintptr, 0, len(list))
-		for _, line := range list {
-			_, ok := lookup[line]
-			if ok {
-				break
-			}
-			uniqueList = append(uniqueList, line)
-			lookup[line] = struct{}{}
-		}
-		pcs[idx] = uniqueList
-	}
+	pcs = uniquePCs(pcs)
 	final := make([]uintptr, 0, len(pcs)*3)
 	for _, list := range pcs {
 		final = append(final, list...)
@@ -477,16 +703,19 @@ func RuntimeFrames(err error) *runtime.Frames {
 }
 
 func StacktraceFromPcs(err error) string {
-	pcs := ProgramCounters(err)
-	frames := runtime.CallersFrames(pcs)
-	frame, hasMore := frames.Next()
-	lines := make([]string, 0, len(pcs))
-	if !hasMore {
-		lines = append(lines, frame.File+":"+strconv.Itoa(frame.Line)+":"+frame.Function+"()")
-	}
-	for hasMore {
-		lines = append(lines, frame.File+":"+strconv.Itoa(frame.Line)+":"+frame.Function+"()")
-		frame, hasMore = frames.Next()
+	opts := currentTraceOptions()
+	filtered := opts.filter(traceFrames(Frames(err)))
+	if len(filtered) == 0 {
+		filtered = []traceFrame{{}}
+	}
+
+	lines := make([]string, 0, len(filtered))
+	for _, tf := range filtered {
+		if tf.collapsed > 0 {
+			lines = append(lines, tf.collapsedLine())
+			continue
+		}
+		lines = append(lines, opts.path(tf.Function, tf.File)+":"+strconv.Itoa(tf.Line)+":"+tf.Function+"()")
 	}
 
 	return strings.Join(lines, "\n")
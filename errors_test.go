@@ -89,7 +89,7 @@ func TestNew(t *testing.T) {
 	message := "friendly error message"
 	want := Error{
 		message: message,
-		eType:   defaultErrType,
+		eType:   Default().config.DefaultType,
 	}
 	e := New(message)
 	e.pcs = nil
@@ -105,7 +105,8 @@ func TestErrorf(t *testing.T) {
 	message := "friendly error message"
 	want := Error{
 		message: fmt.Sprintf(format, message),
-		eType:   defaultErrType,
+		eType:   Default().config.DefaultType,
+		format:  format,
 	}
 	e := Errorf(format, message)
 	e.pcs = nil
@@ -137,7 +138,7 @@ func TestSetDefaultType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			before := defaultErrType
+			before := Default().config.DefaultType
 			SetDefaultType(tt.args.e)
 			err := New(tt.args.message)
 			// resetting to previous value to stop messing with the entire package
@@ -0,0 +1,264 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultStackDepth is the default maximum number of program counters captured for an error
+const DefaultStackDepth = 128
+
+// Classifier returns the error type of an error which is not of type *Error, e.g. sql.ErrNoRows as
+// TypeNotFound. The boolean should be false if the error is not recognized
+type Classifier func(err error) (errType, bool)
+
+// Config is the configuration of a Factory
+type Config struct {
+	// DefaultType is the type of errors created using New, Newf and Errorf
+	DefaultType errType
+	// StackDepth is the maximum number of program counters captured. DefaultStackDepth is used
+	// if it's <= 0
+	StackDepth int
+	// HTTPStatus, if set, maps an error type to HTTP status code. If it returns 0, the built-in
+	// mapping is used
+	HTTPStatus func(et errType) int
+	// GRPCStatus, if set, maps an error type to GRPC status code. If it returns codes.OK, the
+	// built-in mapping is used
+	GRPCStatus func(et errType) codes.Code
+	// ExitCode, if set, maps an error type to process exit code. If it returns 0, the built-in
+	// mapping is used
+	ExitCode func(et errType) int
+	// Classifiers are used in order to determine the type of errors which are not of type *Error,
+	// e.g. while wrapping them. Context errors are classified after them
+	Classifiers []Classifier
+	// Hooks are called for every error created using the factory, before the hooks registered
+	// using RegisterHook
+	Hooks []Hook
+	// Clock, if set, is used to record the creation time of errors, e.g. time.Now. Refer (*Error).Time
+	Clock func() time.Time
+}
+
+//go:generate go run gen_factory.go
+
+// Factory creates errors using its configuration. A Factory is immutable and safe for concurrent
+// use. The package level functions use the default factory, refer SetDefault. The typed helpers
+// (NotFound, ValidationErr etc.) are generated, refer gen_factory.go
+type Factory struct {
+	config Config
+}
+
+var defaultFactory atomic.Pointer[Factory]
+
+func init() {
+	defaultFactory.Store(NewFactory(Config{}))
+}
+
+// NewFactory returns a new Factory with the given configuration
+func NewFactory(config Config) *Factory {
+	if config.StackDepth <= 0 {
+		config.StackDepth = DefaultStackDepth
+	}
+	config.Classifiers = append([]Classifier(nil), config.Classifiers...)
+	config.Hooks = append([]Hook(nil), config.Hooks...)
+	return &Factory{config: config}
+}
+
+// Default returns the factory used by the package level functions
+func Default() *Factory {
+	return defaultFactory.Load()
+}
+
+// SetDefault sets the factory used by the package level functions. Since this affects every
+// package in the process using this package, it should ideally only be called by main
+func SetDefault(f *Factory) {
+	if f == nil {
+		f = NewFactory(Config{})
+	}
+	defaultFactory.Store(f)
+}
+
+// updateDefault atomically replaces the default factory with one using the updated configuration
+func updateDefault(update func(config *Config)) {
+	for {
+		current := defaultFactory.Load()
+		config := current.Config()
+		update(&config)
+		if defaultFactory.CompareAndSwap(current, NewFactory(config)) {
+			return
+		}
+	}
+}
+
+// Config returns a copy of the configuration of the factory
+func (f *Factory) Config() Config {
+	config := f.config
+	config.Classifiers = append([]Classifier(nil), f.config.Classifiers...)
+	config.Hooks = append([]Hook(nil), f.config.Hooks...)
+	return config
+}
+
+func (f *Factory) capture(e error, message string, etype errType, skip int) *Error {
+	pcs := make([]uintptr, f.config.StackDepth)
+	_ = runtime.Callers(skip, pcs)
+	err := &Error{
+		original: e,
+		message:  message,
+		eType:    etype,
+		pcs:      pcs,
+		pc:       pcs[0] - 1,
+	}
+	if f.config.Clock != nil {
+		err.at = f.config.Clock()
+	}
+	return err
+}
+
+func (f *Factory) newerr(e error, message string, etype errType, skip int) *Error {
+	return f.Build().Type(etype).newerr(e, message, skip+1)
+}
+
+func (f *Factory) newerrf(e error, etype errType, skip int, format string, args ...any) *Error {
+	return f.Build().Type(etype).newerrf(e, skip+1, format, args...)
+}
+
+// errType returns the type of err, using the classifiers for errors which are not of type *Error
+func (f *Factory) errType(err error) errType {
+	e, _ := asError(err)
+	if e != nil {
+		return e.Type()
+	}
+
+	if err != nil {
+		for _, classify := range f.config.Classifiers {
+			if et, ok := classify(err); ok {
+				return et
+			}
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return TypeContextTimedout
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return TypeContextCancelled
+	}
+
+	return TypeInternal
+}
+
+func (f *Factory) httpStatusCode(et errType) int {
+	if f.config.HTTPStatus != nil {
+		if status := f.config.HTTPStatus(et); status != 0 {
+			return status
+		}
+	}
+	return httpStatusCode(et)
+}
+
+func (f *Factory) grpcStatusCode(et errType) codes.Code {
+	if f.config.GRPCStatus != nil {
+		if status := f.config.GRPCStatus(et); status != codes.OK {
+			return status
+		}
+	}
+	return grpcStatusCode(et)
+}
+
+// New is same as the package level New, but uses the configuration of the factory
+func (f *Factory) New(msg string) *Error {
+	return f.newerr(nil, msg, f.config.DefaultType, 3)
+}
+
+// Newf is same as the package level Newf, but uses the configuration of the factory
+func (f *Factory) Newf(format string, args ...any) *Error {
+	return f.newerrf(nil, f.config.DefaultType, 4, format, args...)
+}
+
+// Errorf is same as the package level Errorf, but uses the configuration of the factory
+func (f *Factory) Errorf(format string, args ...any) *Error {
+	return f.newerrf(nil, f.config.DefaultType, 4, format, args...)
+}
+
+// NewWithType is same as the package level NewWithType, but uses the configuration of the factory
+func (f *Factory) NewWithType(msg string, etype errType) *Error {
+	return f.newerr(nil, msg, etype, 3)
+}
+
+// NewWithTypef is same as the package level NewWithTypef, but uses the configuration of the factory
+func (f *Factory) NewWithTypef(etype errType, format string, args ...any) *Error {
+	return f.newerrf(nil, etype, 4, format, args...)
+}
+
+// Wrap is same as the package level Wrap, but uses the configuration of the factory
+func (f *Factory) Wrap(original error, msg ...string) *Error {
+	message := strings.Join(msg, ". ")
+	return f.newerr(original, message, f.errType(original), 3)
+}
+
+// Wrapf is same as the package level Wrapf, but uses the configuration of the factory
+func (f *Factory) Wrapf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, f.errType(original), 4, format, args...)
+}
+
+// HTTPStatusCode is same as the package level HTTPStatusCode, but uses the configuration of the factory
+func (f *Factory) HTTPStatusCode(err error) (int, bool) {
+	derr, _ := asError(err)
+	if derr != nil {
+		return f.httpStatusCode(derr.Type()), true
+	}
+
+	// Since TypeInternal is the default returned by errType, it is ignored.
+	if et := f.errType(err); et != TypeInternal {
+		return f.httpStatusCode(et), false
+	}
+
+	jerr, _ := err.(*joinError)
+	if jerr != nil {
+		elen := len(jerr.errs)
+		isErr := true
+		for i := elen - 1; i >= 0; i-- {
+			code, isE := f.HTTPStatusCode(jerr.errs[i])
+			isErr = isE && isErr
+			if isE {
+				return code, isErr
+			}
+		}
+	}
+
+	return f.httpStatusCode(TypeInternal), false
+}
+
+// GRPCStatusCode is same as the package level GRPCStatusCode, but uses the configuration of the factory
+func (f *Factory) GRPCStatusCode(err error) (codes.Code, bool) {
+	derr, _ := asError(err)
+	if derr != nil {
+		return f.grpcStatusCode(derr.Type()), true
+	}
+
+	// Since TypeInternal is the default returned by errType, it is ignored.
+	if et := f.errType(err); et != TypeInternal {
+		return f.grpcStatusCode(et), false
+	}
+
+	jerr, _ := err.(*joinError)
+	if jerr != nil {
+		elen := len(jerr.errs)
+		isErr := true
+		for i := elen - 1; i >= 0; i-- {
+			code, isE := f.GRPCStatusCode(jerr.errs[i])
+			isErr = isE && isErr
+			if isE {
+				return code, isErr
+			}
+		}
+	}
+
+	return codes.Unknown, false
+}
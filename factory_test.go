@@ -0,0 +1,177 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestFactory(t *testing.T) {
+	f := NewFactory(Config{
+		DefaultType: TypeValidation,
+		StackDepth:  2,
+		HTTPStatus: func(et errType) int {
+			if et == TypeValidation {
+				return http.StatusBadRequest
+			}
+			return 0
+		},
+		GRPCStatus: func(et errType) codes.Code {
+			if et == TypeNotFound {
+				return codes.FailedPrecondition
+			}
+			return codes.OK
+		},
+		Classifiers: []Classifier{
+			func(err error) (errType, bool) {
+				if errors.Is(err, sql.ErrNoRows) {
+					return TypeNotFound, true
+				}
+				return 0, false
+			},
+		},
+	})
+
+	err := f.New("invalid")
+	if err.Type() != TypeValidation {
+		t.Errorf("New().Type() = %v, want %v", err.Type(), TypeValidation)
+	}
+	if len(err.ProgramCounters()) != 2 {
+		t.Errorf("len(ProgramCounters()) = %d, want 2", len(err.ProgramCounters()))
+	}
+	if !strings.Contains(err.Error(), "factory_test.go") {
+		t.Errorf("Error() = %q, expected file & line of the call site", err.Error())
+	}
+	if got := f.Errorf("invalid %s", "email"); got.Type() != TypeValidation || got.Message() != "invalid email" {
+		t.Errorf("Errorf() = %v, type %v", got, got.Type())
+	}
+	if got := f.Newf("invalid %d", 1); !strings.Contains(got.Error(), "factory_test.go") {
+		t.Errorf("Newf().Error() = %q, expected file & line of the call site", got.Error())
+	}
+
+	wrapped := f.Wrap(fmt.Errorf("query: %w", sql.ErrNoRows), "get user")
+	if wrapped.Type() != TypeNotFound {
+		t.Errorf("Wrap().Type() = %v, want %v", wrapped.Type(), TypeNotFound)
+	}
+	if got := Wrap(sql.ErrNoRows).Type(); got != TypeInternal {
+		t.Errorf("default factory Wrap().Type() = %v, want %v", got, TypeInternal)
+	}
+	if got := f.Wrapf(fmt.Errorf("timeout: %w", context.DeadlineExceeded), "get %s", "user").Type(); got != TypeContextTimedout {
+		t.Errorf("Wrapf().Type() = %v, want %v", got, TypeContextTimedout)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		http int
+		grpc codes.Code
+		ok   bool
+	}{
+		{name: "mapped", err: f.New("invalid"), http: http.StatusBadRequest, grpc: codes.InvalidArgument, ok: true},
+		{name: "fallback", err: f.NewWithType("dup", TypeDuplicate), http: http.StatusConflict, grpc: codes.AlreadyExists, ok: true},
+		{name: "classified", err: sql.ErrNoRows, http: http.StatusNotFound, grpc: codes.FailedPrecondition, ok: false},
+		{name: "foreign", err: errors.New("oops"), http: http.StatusInternalServerError, grpc: codes.Unknown, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := f.HTTPStatusCode(tt.err)
+			if status != tt.http || ok != tt.ok {
+				t.Errorf("HTTPStatusCode() = (%d, %v), want (%d, %v)", status, ok, tt.http, tt.ok)
+			}
+			code, ok := f.GRPCStatusCode(tt.err)
+			if code != tt.grpc || ok != tt.ok {
+				t.Errorf("GRPCStatusCode() = (%v, %v), want (%v, %v)", code, ok, tt.grpc, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFactoryConfigIsImmutable(t *testing.T) {
+	classifiers := []Classifier{func(error) (errType, bool) { return TypeNotFound, true }}
+	f := NewFactory(Config{Classifiers: classifiers})
+	classifiers[0] = func(error) (errType, bool) { return TypeEmpty, true }
+
+	config := f.Config()
+	config.Classifiers[0] = classifiers[0]
+	if got := f.Wrap(errors.New("oops")).Type(); got != TypeNotFound {
+		t.Errorf("Wrap().Type() = %v, want %v", got, TypeNotFound)
+	}
+	if config.StackDepth != DefaultStackDepth {
+		t.Errorf("StackDepth = %d, want %d", config.StackDepth, DefaultStackDepth)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	before := Default()
+	t.Cleanup(func() { SetDefault(before) })
+
+	SetDefault(NewFactory(Config{DefaultType: TypeNotFound}))
+	if got := New("hello").Type(); got != TypeNotFound {
+		t.Errorf("New().Type() = %v, want %v", got, TypeNotFound)
+	}
+
+	SetDefault(nil)
+	if got := New("hello").Type(); got != TypeInternal {
+		t.Errorf("New().Type() = %v, want %v", got, TypeInternal)
+	}
+}
+
+func TestSetDefaultTypeConcurrent(t *testing.T) {
+	before := Default()
+	t.Cleanup(func() { SetDefault(before) })
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultType(TypeValidation)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = New("hello")
+		}()
+	}
+	wg.Wait()
+
+	if got := New("hello").Type(); got != TypeValidation {
+		t.Errorf("New().Type() = %v, want %v", got, TypeValidation)
+	}
+}
+
+func TestFactoryTypedHelpers(t *testing.T) {
+	f := NewFactory(Config{StackDepth: 2})
+	original := errors.New("no rows")
+
+	tests := []struct {
+		name  string
+		err   *Error
+		etype errType
+		msg   string
+	}{
+		{name: "new", err: f.NotFound("user not found"), etype: TypeNotFound, msg: "user not found"},
+		{name: "newf", err: f.Validationf("invalid %s", "email"), etype: TypeValidation, msg: "invalid email"},
+		{name: "wrap", err: f.DuplicateErr(original, "user exists"), etype: TypeDuplicate, msg: "user exists"},
+		{name: "wrapf", err: f.ContextTimedoutErrf(original, "query %d", 1), etype: TypeContextTimedout, msg: "query 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Type() != tt.etype || tt.err.Message() != tt.msg {
+				t.Errorf("got %v: %q, want %v: %q", tt.err.Type(), tt.err.Message(), tt.etype, tt.msg)
+			}
+			if len(tt.err.ProgramCounters()) != 2 {
+				t.Errorf("ProgramCounters() = %d, want the StackDepth of the factory", len(tt.err.ProgramCounters()))
+			}
+			if frame := tt.err.Frames()[0]; frame.Function != "github.com/naughtygopher/errors.TestFactoryTypedHelpers" {
+				t.Errorf("caller = %s, want the caller of the helper", frame.Function)
+			}
+		})
+	}
+}
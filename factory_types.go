@@ -0,0 +1,283 @@
+// Code generated by gen_factory.go; DO NOT EDIT.
+
+package errors
+
+// Internal returns a new error of type TypeInternal, using the configuration of the factory
+func (f *Factory) Internal(message string) *Error {
+	return f.newerr(nil, message, TypeInternal, 3)
+}
+
+// Internalf returns a new error of type TypeInternal with formatted message, using the configuration of the factory
+func (f *Factory) Internalf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeInternal, 4, format, args...)
+}
+
+// InternalErr wraps the original error as TypeInternal, using the configuration of the factory
+func (f *Factory) InternalErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeInternal, 3)
+}
+
+// InternalErrf wraps the original error as TypeInternal with formatted message, using the configuration of the factory
+func (f *Factory) InternalErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeInternal, 4, format, args...)
+}
+
+// Validation returns a new error of type TypeValidation, using the configuration of the factory
+func (f *Factory) Validation(message string) *Error {
+	return f.newerr(nil, message, TypeValidation, 3)
+}
+
+// Validationf returns a new error of type TypeValidation with formatted message, using the configuration of the factory
+func (f *Factory) Validationf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeValidation, 4, format, args...)
+}
+
+// ValidationErr wraps the original error as TypeValidation, using the configuration of the factory
+func (f *Factory) ValidationErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeValidation, 3)
+}
+
+// ValidationErrf wraps the original error as TypeValidation with formatted message, using the configuration of the factory
+func (f *Factory) ValidationErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeValidation, 4, format, args...)
+}
+
+// InputBody returns a new error of type TypeInputBody, using the configuration of the factory
+func (f *Factory) InputBody(message string) *Error {
+	return f.newerr(nil, message, TypeInputBody, 3)
+}
+
+// InputBodyf returns a new error of type TypeInputBody with formatted message, using the configuration of the factory
+func (f *Factory) InputBodyf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeInputBody, 4, format, args...)
+}
+
+// InputBodyErr wraps the original error as TypeInputBody, using the configuration of the factory
+func (f *Factory) InputBodyErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeInputBody, 3)
+}
+
+// InputBodyErrf wraps the original error as TypeInputBody with formatted message, using the configuration of the factory
+func (f *Factory) InputBodyErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeInputBody, 4, format, args...)
+}
+
+// Duplicate returns a new error of type TypeDuplicate, using the configuration of the factory
+func (f *Factory) Duplicate(message string) *Error {
+	return f.newerr(nil, message, TypeDuplicate, 3)
+}
+
+// Duplicatef returns a new error of type TypeDuplicate with formatted message, using the configuration of the factory
+func (f *Factory) Duplicatef(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeDuplicate, 4, format, args...)
+}
+
+// DuplicateErr wraps the original error as TypeDuplicate, using the configuration of the factory
+func (f *Factory) DuplicateErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeDuplicate, 3)
+}
+
+// DuplicateErrf wraps the original error as TypeDuplicate with formatted message, using the configuration of the factory
+func (f *Factory) DuplicateErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeDuplicate, 4, format, args...)
+}
+
+// Unauthenticated returns a new error of type TypeUnauthenticated, using the configuration of the factory
+func (f *Factory) Unauthenticated(message string) *Error {
+	return f.newerr(nil, message, TypeUnauthenticated, 3)
+}
+
+// Unauthenticatedf returns a new error of type TypeUnauthenticated with formatted message, using the configuration of the factory
+func (f *Factory) Unauthenticatedf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeUnauthenticated, 4, format, args...)
+}
+
+// UnauthenticatedErr wraps the original error as TypeUnauthenticated, using the configuration of the factory
+func (f *Factory) UnauthenticatedErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeUnauthenticated, 3)
+}
+
+// UnauthenticatedErrf wraps the original error as TypeUnauthenticated with formatted message, using the configuration of the factory
+func (f *Factory) UnauthenticatedErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeUnauthenticated, 4, format, args...)
+}
+
+// Unauthorized returns a new error of type TypeUnauthorized, using the configuration of the factory
+func (f *Factory) Unauthorized(message string) *Error {
+	return f.newerr(nil, message, TypeUnauthorized, 3)
+}
+
+// Unauthorizedf returns a new error of type TypeUnauthorized with formatted message, using the configuration of the factory
+func (f *Factory) Unauthorizedf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeUnauthorized, 4, format, args...)
+}
+
+// UnauthorizedErr wraps the original error as TypeUnauthorized, using the configuration of the factory
+func (f *Factory) UnauthorizedErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeUnauthorized, 3)
+}
+
+// UnauthorizedErrf wraps the original error as TypeUnauthorized with formatted message, using the configuration of the factory
+func (f *Factory) UnauthorizedErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeUnauthorized, 4, format, args...)
+}
+
+// Empty returns a new error of type TypeEmpty, using the configuration of the factory
+func (f *Factory) Empty(message string) *Error {
+	return f.newerr(nil, message, TypeEmpty, 3)
+}
+
+// Emptyf returns a new error of type TypeEmpty with formatted message, using the configuration of the factory
+func (f *Factory) Emptyf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeEmpty, 4, format, args...)
+}
+
+// EmptyErr wraps the original error as TypeEmpty, using the configuration of the factory
+func (f *Factory) EmptyErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeEmpty, 3)
+}
+
+// EmptyErrf wraps the original error as TypeEmpty with formatted message, using the configuration of the factory
+func (f *Factory) EmptyErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeEmpty, 4, format, args...)
+}
+
+// NotFound returns a new error of type TypeNotFound, using the configuration of the factory
+func (f *Factory) NotFound(message string) *Error {
+	return f.newerr(nil, message, TypeNotFound, 3)
+}
+
+// NotFoundf returns a new error of type TypeNotFound with formatted message, using the configuration of the factory
+func (f *Factory) NotFoundf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeNotFound, 4, format, args...)
+}
+
+// NotFoundErr wraps the original error as TypeNotFound, using the configuration of the factory
+func (f *Factory) NotFoundErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeNotFound, 3)
+}
+
+// NotFoundErrf wraps the original error as TypeNotFound with formatted message, using the configuration of the factory
+func (f *Factory) NotFoundErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeNotFound, 4, format, args...)
+}
+
+// MaximumAttempts returns a new error of type TypeMaximumAttempts, using the configuration of the factory
+func (f *Factory) MaximumAttempts(message string) *Error {
+	return f.newerr(nil, message, TypeMaximumAttempts, 3)
+}
+
+// MaximumAttemptsf returns a new error of type TypeMaximumAttempts with formatted message, using the configuration of the factory
+func (f *Factory) MaximumAttemptsf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeMaximumAttempts, 4, format, args...)
+}
+
+// MaximumAttemptsErr wraps the original error as TypeMaximumAttempts, using the configuration of the factory
+func (f *Factory) MaximumAttemptsErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeMaximumAttempts, 3)
+}
+
+// MaximumAttemptsErrf wraps the original error as TypeMaximumAttempts with formatted message, using the configuration of the factory
+func (f *Factory) MaximumAttemptsErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeMaximumAttempts, 4, format, args...)
+}
+
+// SubscriptionExpired returns a new error of type TypeSubscriptionExpired, using the configuration of the factory
+func (f *Factory) SubscriptionExpired(message string) *Error {
+	return f.newerr(nil, message, TypeSubscriptionExpired, 3)
+}
+
+// SubscriptionExpiredf returns a new error of type TypeSubscriptionExpired with formatted message, using the configuration of the factory
+func (f *Factory) SubscriptionExpiredf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeSubscriptionExpired, 4, format, args...)
+}
+
+// SubscriptionExpiredErr wraps the original error as TypeSubscriptionExpired, using the configuration of the factory
+func (f *Factory) SubscriptionExpiredErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeSubscriptionExpired, 3)
+}
+
+// SubscriptionExpiredErrf wraps the original error as TypeSubscriptionExpired with formatted message, using the configuration of the factory
+func (f *Factory) SubscriptionExpiredErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeSubscriptionExpired, 4, format, args...)
+}
+
+// DownstreamDependencyTimedout returns a new error of type TypeDownstreamDependencyTimedout, using the configuration of the factory
+func (f *Factory) DownstreamDependencyTimedout(message string) *Error {
+	return f.newerr(nil, message, TypeDownstreamDependencyTimedout, 3)
+}
+
+// DownstreamDependencyTimedoutf returns a new error of type TypeDownstreamDependencyTimedout with formatted message, using the configuration of the factory
+func (f *Factory) DownstreamDependencyTimedoutf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeDownstreamDependencyTimedout, 4, format, args...)
+}
+
+// DownstreamDependencyTimedoutErr wraps the original error as TypeDownstreamDependencyTimedout, using the configuration of the factory
+func (f *Factory) DownstreamDependencyTimedoutErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeDownstreamDependencyTimedout, 3)
+}
+
+// DownstreamDependencyTimedoutErrf wraps the original error as TypeDownstreamDependencyTimedout with formatted message, using the configuration of the factory
+func (f *Factory) DownstreamDependencyTimedoutErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeDownstreamDependencyTimedout, 4, format, args...)
+}
+
+// NotImplemented returns a new error of type TypeNotImplemented, using the configuration of the factory
+func (f *Factory) NotImplemented(message string) *Error {
+	return f.newerr(nil, message, TypeNotImplemented, 3)
+}
+
+// NotImplementedf returns a new error of type TypeNotImplemented with formatted message, using the configuration of the factory
+func (f *Factory) NotImplementedf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeNotImplemented, 4, format, args...)
+}
+
+// NotImplementedErr wraps the original error as TypeNotImplemented, using the configuration of the factory
+func (f *Factory) NotImplementedErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeNotImplemented, 3)
+}
+
+// NotImplementedErrf wraps the original error as TypeNotImplemented with formatted message, using the configuration of the factory
+func (f *Factory) NotImplementedErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeNotImplemented, 4, format, args...)
+}
+
+// ContextTimedout returns a new error of type TypeContextTimedout, using the configuration of the factory
+func (f *Factory) ContextTimedout(message string) *Error {
+	return f.newerr(nil, message, TypeContextTimedout, 3)
+}
+
+// ContextTimedoutf returns a new error of type TypeContextTimedout with formatted message, using the configuration of the factory
+func (f *Factory) ContextTimedoutf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeContextTimedout, 4, format, args...)
+}
+
+// ContextTimedoutErr wraps the original error as TypeContextTimedout, using the configuration of the factory
+func (f *Factory) ContextTimedoutErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeContextTimedout, 3)
+}
+
+// ContextTimedoutErrf wraps the original error as TypeContextTimedout with formatted message, using the configuration of the factory
+func (f *Factory) ContextTimedoutErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeContextTimedout, 4, format, args...)
+}
+
+// ContextCancelled returns a new error of type TypeContextCancelled, using the configuration of the factory
+func (f *Factory) ContextCancelled(message string) *Error {
+	return f.newerr(nil, message, TypeContextCancelled, 3)
+}
+
+// ContextCancelledf returns a new error of type TypeContextCancelled with formatted message, using the configuration of the factory
+func (f *Factory) ContextCancelledf(format string, args ...any) *Error {
+	return f.newerrf(nil, TypeContextCancelled, 4, format, args...)
+}
+
+// ContextCancelledErr wraps the original error as TypeContextCancelled, using the configuration of the factory
+func (f *Factory) ContextCancelledErr(original error, message string) *Error {
+	return f.newerr(original, message, TypeContextCancelled, 3)
+}
+
+// ContextCancelledErrf wraps the original error as TypeContextCancelled with formatted message, using the configuration of the factory
+func (f *Factory) ContextCancelledErrf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, TypeContextCancelled, 4, format, args...)
+}
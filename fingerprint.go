@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint returns a stable identifier of the site where the innermost *Error in the chain
+// was created. It can be used to group/deduplicate identical failures. Refer the package level
+// Fingerprint function for details.
+func (e *Error) Fingerprint() string {
+	return Fingerprint(e)
+}
+
+// Fingerprint returns a stable identifier of the error, computed using the type, the code (or
+// format string/message if code is not set) and the origin frame (i.e. the function, file & line
+// relative to the beginning of the function, where it was created) of the innermost *Error in the
+// chain. Arguments of formatted messages are not considered, so errors created at the same site with
+// different arguments have the same fingerprint. Absolute line numbers & callers of the origin are
+// ignored, so that the fingerprint is stable across changes elsewhere in the file, and across
+// different call paths leading to the same failure.
+// File paths are module qualified (i.e. independent of the build machine). If the chain does not
+// have any *Error, the fingerprint is computed using the Go type and the output of Error().
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var innermost *Error
+	walk(err, func(e *Error) bool {
+		innermost = e
+		return true
+	})
+
+	hash := fnv.New64a()
+	if innermost == nil {
+		_, _ = fmt.Fprintf(hash, "%T\n%s", err, err.Error())
+		return strconv.FormatUint(hash.Sum64(), 16)
+	}
+
+	_, _ = hash.Write([]byte(innermost.eType.String()))
+	_, _ = hash.Write([]byte("\n"))
+	switch {
+	case innermost.code != "":
+		_, _ = hash.Write([]byte(innermost.code))
+	case innermost.format != "":
+		_, _ = hash.Write([]byte(innermost.format))
+	default:
+		_, _ = hash.Write([]byte(innermost.message))
+	}
+
+	if pcs := innermost.ProgramCounters(); len(pcs) > 0 {
+		frame, _ := runtime.CallersFrames(pcs[:1]).Next()
+		_, _ = hash.Write([]byte("\n"))
+		_, _ = hash.Write([]byte(frame.Function))
+		_, _ = hash.Write([]byte(" "))
+		_, _ = hash.Write([]byte(modulePath(frame.Function, frame.File)))
+		if line := lineInFunc(frame); line >= 0 {
+			_, _ = hash.Write([]byte(":"))
+			_, _ = hash.Write([]byte(strconv.Itoa(line)))
+		}
+	}
+
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// lineInFunc returns the line of the frame relative to the beginning of its function, -1 if not
+// available. The runtime does not export the start line of functions, so it's read from the
+// unexported field of runtime.Frame, which is available since Go 1.21 (including for inlined frames)
+func lineInFunc(frame runtime.Frame) int {
+	start := reflect.ValueOf(frame).FieldByName("startLine")
+	if !start.IsValid() || !start.CanInt() || start.Int() <= 0 {
+		return -1
+	}
+	return frame.Line - int(start.Int())
+}
+
+// funcPackage returns the package path of a fully qualified function name as reported by
+// runtime.Frame, e.g. "github.com/naughtygopher/errors" for "github.com/naughtygopher/errors.(*Error).Error".
+// Dots in the last element of the package path are escaped by the linker (e.g. "gopkg.in/yaml%2ev3"),
+// and are unescaped
+func funcPackage(function string) string {
+	// type parameters of generic functions may have package paths, e.g. "pkg.Map[pkg/types.T]"
+	name := function
+	if bracket := strings.Index(name, "["); bracket >= 0 {
+		name = name[:bracket]
+	}
+
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+	return strings.ReplaceAll(name, "%2e", ".")
+}
+
+// modulePath returns the module qualified path of the file, e.g. github.com/naughtygopher/errors/errors.go
+func modulePath(function string, file string) string {
+	pkg := funcPackage(function)
+	if pkg == "" || pkg == "main" {
+		return path.Base(file)
+	}
+	return pkg + "/" + path.Base(file)
+}
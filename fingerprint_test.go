@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func fingerprintSite(id int) *Error {
+	return NotFoundf("user %d not found", id)
+}
+
+func TestFingerprint(t *testing.T) {
+	list := make([]*Error, 0, 2)
+	for id := range 2 {
+		list = append(list, fingerprintSite(id))
+	}
+	first, second := list[0], list[1]
+	if first.Fingerprint() != second.Fingerprint() {
+		t.Errorf("Fingerprint() differs for the same site: %s, %s", first.Fingerprint(), second.Fingerprint())
+	}
+
+	other := NotFoundf("user %d not found", 1)
+	if first.Fingerprint() == other.Fingerprint() {
+		t.Error("Fingerprint() should differ for different sites")
+	}
+
+	wrapped := Wrap(first, "get user")
+	if Fingerprint(wrapped) != first.Fingerprint() {
+		t.Error("Fingerprint() should be of the innermost *Error")
+	}
+
+	if Fingerprint(Join(errors.New("std"), first)) != first.Fingerprint() {
+		t.Error("Fingerprint() should consider joined errors")
+	}
+
+	std := errors.New("std error")
+	if Fingerprint(std) != Fingerprint(errors.New("std error")) {
+		t.Error("Fingerprint() should be stable for non *Error")
+	}
+	if Fingerprint(std) == Fingerprint(errors.New("other std error")) {
+		t.Error("Fingerprint() should differ for different non *Error")
+	}
+
+	if Fingerprint(nil) != "" {
+		t.Error("Fingerprint(nil) should be empty")
+	}
+}
+
+func TestFingerprintCode(t *testing.T) {
+	codes := make([]string, 0, 2)
+	for _, msg := range []string{"first", "second"} {
+		codes = append(codes, NewWithCode("USR404", msg, TypeNotFound).Fingerprint())
+	}
+	if codes[0] != codes[1] {
+		t.Error("Fingerprint() should use the code instead of the message")
+	}
+}
+
+func TestFingerprintSerialized(t *testing.T) {
+	err := WrapWithCode(fingerprintSite(1), "USR404", "get user")
+	if Code(err) != "USR404" {
+		t.Errorf("Code() = %q, want %q", Code(err), "USR404")
+	}
+
+	raw, _ := json.Marshal(err)
+	if !strings.Contains(string(raw), `"fingerprint":"`+err.Fingerprint()+`"`) {
+		t.Errorf("fingerprint missing in %s", raw)
+	}
+	if !strings.Contains(string(raw), `"code":"USR404"`) {
+		t.Errorf("code missing in %s", raw)
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	tests := map[string]string{
+		"github.com/naughtygopher/errors.(*Error).Error": "github.com/naughtygopher/errors",
+		"github.com/naughtygopher/errors.Map[...]":       "github.com/naughtygopher/errors",
+		"main.main":                                             "main",
+		"runtime.goexit":                                        "runtime",
+		"gopkg.in/yaml%2ev3.(*Decoder).Decode":                  "gopkg.in/yaml.v3",
+		"gopkg.in/yaml%2ev3.unmarshal[...]":                     "gopkg.in/yaml.v3",
+		"github.com/naughtygopher/errors.AsType[net/url.Error]": "github.com/naughtygopher/errors",
+	}
+	for function, want := range tests {
+		if got := funcPackage(function); got != want {
+			t.Errorf("funcPackage(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
+
+func fingerprintCaller(id int) *Error {
+	return fingerprintSite(id)
+}
+
+func TestFingerprintCallers(t *testing.T) {
+	direct := fingerprintSite(1)
+	indirect := fingerprintCaller(2)
+	if direct.Fingerprint() != indirect.Fingerprint() {
+		t.Error("Fingerprint() should not depend on the callers of the origin")
+	}
+
+	if NotFound("not found").Fingerprint() == Validation("not found").Fingerprint() {
+		t.Error("Fingerprint() should differ for different types")
+	}
+	if NotFound("user not found").Fingerprint() != NotFound("user not found").Fingerprint() {
+		t.Error("Fingerprint() should not depend on the line number of the origin")
+	}
+}
+
+func TestFingerprintSitesInFunction(t *testing.T) {
+	eof := Wrap(io.EOF)
+	notExist := Wrap(os.ErrNotExist)
+	if eof.Fingerprint() == notExist.Fingerprint() {
+		t.Error("Fingerprint() should differ for different sites in the same function")
+	}
+
+	frame, _ := runtime.CallersFrames(eof.ProgramCounters()[:1]).Next()
+	if got := lineInFunc(frame); got != 1 {
+		t.Errorf("lineInFunc() = %d, want 1", got)
+	}
+}
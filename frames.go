@@ -0,0 +1,168 @@
+package errors
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Frame is a single stack frame
+type Frame struct {
+	// Function is the fully qualified function name, e.g. github.com/naughtygopher/errors.New
+	Function string
+	// Package is the import path of the package of the function
+	Package string
+	// File is the absolute file path as available in the binary
+	File string
+	Line int
+	PC   uintptr
+	// InApp is true if the frame is neither of the Go standard library nor of a dependency
+	InApp bool
+	// Message is the message of the *Error, to whose stacktrace this frame belongs
+	Message string
+
+	err *Error
+}
+
+// Err returns the *Error to whose stacktrace this frame belongs, nil if the frame belongs to an
+// error which is not of type *Error
+func (f Frame) Err() *Error {
+	return f.err
+}
+
+// ShortFile returns the file name, without the directory
+func (f Frame) ShortFile() string {
+	idx := strings.LastIndex(f.File, "/")
+	return f.File[idx+1:]
+}
+
+func newFrame(rf runtime.Frame, message string, e *Error) Frame {
+	pkg := funcPackage(rf.Function)
+	return Frame{
+		Function: rf.Function,
+		Package:  pkg,
+		File:     rf.File,
+		Line:     rf.Line,
+		PC:       rf.PC,
+		InApp:    isInApp(pkg, rf.File),
+		Message:  message,
+		err:      e,
+	}
+}
+
+// Frames returns the stack frames of the error
+func (e *Error) Frames() []Frame {
+	return pcFrames(e.ProgramCounters(), e.text(), e)
+}
+
+// pcFrames returns the frames of the program counters. e is nil for errors which are not of type *Error
+func pcFrames(pcs []uintptr, message string, e *Error) []Frame {
+	frames := make([]Frame, 0, len(pcs))
+	rframes := runtime.CallersFrames(pcs)
+	for {
+		rf, more := rframes.Next()
+		if rf.Function != "" || rf.File != "" {
+			frames = append(frames, newFrame(rf, message, e))
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// Frames returns the stack frames of all the *Error in the error tree (including joined errors), as
+// well as of other errors which expose their stacktrace (refer ForeignProgramCounters). Frames common
+// to the stacktraces of wrapped errors are deduplicated, and each frame is attributed to the outermost
+// error whose stacktrace has the frame exclusively. The frames of the outermost error are first, and
+// joined errors are traversed depth first.
+func Frames(err error) []Frame {
+	errs := make([]error, 0, 8)
+	pcs := make([][]uintptr, 0, 8)
+	var collect func(err error)
+	collect = func(err error) {
+		for err != nil {
+			if e, ok := asError(err); ok {
+				errs = append(errs, e)
+				pcs = append(pcs, e.ProgramCounters())
+			} else if fpcs := ForeignProgramCounters(err); fpcs != nil {
+				errs = append(errs, err)
+				pcs = append(pcs, fpcs)
+			}
+
+			if je, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, inner := range je.Unwrap() {
+					collect(inner)
+				}
+				return
+			}
+			err = Unwrap(err)
+		}
+	}
+	collect(err)
+
+	pcs = uniquePCs(pcs)
+	frames := make([]Frame, 0, len(pcs)*8)
+	for idx, err := range errs {
+		if e, ok := asError(err); ok {
+			frames = append(frames, pcFrames(pcs[idx], e.text(), e)...)
+		} else {
+			frames = append(frames, pcFrames(pcs[idx], err.Error(), nil)...)
+		}
+	}
+
+	return frames
+}
+
+// uniquePCs removes the program counters which are already available in the ones of wrapped errors
+func uniquePCs(pcs [][]uintptr) [][]uintptr {
+	lookup := map[uintptr]struct{}{}
+	for idx := len(pcs) - 1; idx >= 0; idx-- {
+		list := pcs[idx]
+		uniqueList := make([]uintptr, 0, len(list))
+		for _, line := range list {
+			_, ok := lookup[line]
+			if ok {
+				break
+			}
+			uniqueList = append(uniqueList, line)
+			lookup[line] = struct{}{}
+		}
+		pcs[idx] = uniqueList
+	}
+	return pcs
+}
+
+var mainModule = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	return info.Main.Path
+})
+
+// isInApp returns true if the package is neither of the Go standard library nor of a dependency
+func isInApp(pkg string, file string) bool {
+	if isStdlib(pkg) {
+		return false
+	}
+	if hasPkgPrefix(pkg, []string{"main", mainModule()}) {
+		return true
+	}
+
+	return !strings.Contains(file, "/pkg/mod/") && !strings.Contains(file, "/vendor/") && !strings.Contains(file, "@v")
+}
+
+// hasPkgPrefix returns true if the package is any of the prefixes, or is nested within any of them
+func hasPkgPrefix(pkg string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			continue
+		}
+		if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func framesInner() *Error {
+	return NotFound("inner")
+}
+
+func framesOuter() *Error {
+	return Wrap(framesInner(), "outer")
+}
+
+func TestFrames(t *testing.T) {
+	err := framesOuter()
+
+	frames := err.Frames()
+	if len(frames) < 3 {
+		t.Fatalf("Frames() = %v", frames)
+	}
+	first := frames[0]
+	if first.Function != "github.com/naughtygopher/errors.framesOuter" ||
+		first.Package != "github.com/naughtygopher/errors" ||
+		first.ShortFile() != "frames_test.go" ||
+		first.Line != 14 ||
+		first.PC == 0 ||
+		!first.InApp ||
+		first.Message != "outer" ||
+		first.Err() != err {
+		t.Errorf("unexpected frame %+v", first)
+	}
+	last := frames[len(frames)-1]
+	if last.Function != "runtime.goexit" || last.InApp {
+		t.Errorf("unexpected frame %+v", last)
+	}
+
+	merged := Frames(Wrap(err, "wrapped"))
+	want := []struct {
+		function string
+		message  string
+	}{
+		{function: "github.com/naughtygopher/errors.TestFrames", message: "wrapped"},
+		{function: "github.com/naughtygopher/errors.framesOuter", message: "outer"},
+		{function: "github.com/naughtygopher/errors.framesInner", message: "inner"},
+		{function: "github.com/naughtygopher/errors.framesOuter", message: "inner"},
+		{function: "github.com/naughtygopher/errors.TestFrames", message: "inner"},
+		{function: "testing.tRunner", message: "inner"},
+	}
+	for idx, w := range want {
+		if merged[idx].Function != w.function || merged[idx].Message != w.message {
+			t.Errorf("frame %d = %s (%s), want %s (%s)", idx, merged[idx].Function, merged[idx].Message, w.function, w.message)
+		}
+	}
+	if merged[5].InApp {
+		t.Errorf("stdlib frame should not be in app: %+v", merged[5])
+	}
+
+	if got := Frames(errors.New("std")); len(got) != 0 {
+		t.Errorf("Frames() = %v, want empty", got)
+	}
+}
+
+func TestStacktraceUnchanged(t *testing.T) {
+	err := Wrap(errors.New("original error"), "wrapped error")
+	got := Stacktrace(err)
+	if strings.Contains(got, "runtime.goexit") || strings.Contains(got, "asm_") {
+		t.Errorf("Stacktrace() should not include runtime.goexit: %s", got)
+	}
+	if !strings.HasPrefix(got, "github.com/naughtygopher/errors.TestStacktraceUnchanged(): wrapped error\n\t") {
+		t.Errorf("Stacktrace() = %s", got)
+	}
+	if !strings.HasSuffix(got, "\noriginal error") {
+		t.Errorf("Stacktrace() = %s", got)
+	}
+}
+
+func TestFramesJoined(t *testing.T) {
+	err := Wrap(Join(framesInner(), errors.New("std"), NotFound("second")), "wrapped")
+
+	messages := map[string]bool{}
+	for _, frame := range Frames(err) {
+		messages[frame.Message] = true
+	}
+	for _, msg := range []string{"wrapped", "inner", "second"} {
+		if !messages[msg] {
+			t.Errorf("Frames() should have the frames of %q: %v", msg, messages)
+		}
+	}
+}
+
+func TestTraceFramesStackDepth(t *testing.T) {
+	err := NewFactory(Config{StackDepth: 2}).New("truncated")
+	lines := err.StackTrace()
+	// the message line, followed by both the captured frames
+	if len(lines) != 3 {
+		t.Errorf("StackTrace() = %q, want 3 lines", lines)
+	}
+
+	frames := err.Frames()
+	if got := traceFrames(frames); len(got) != len(frames) {
+		t.Errorf("traceFrames() = %v, want %v", got, frames)
+	}
+}
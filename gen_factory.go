@@ -0,0 +1,70 @@
+//go:build ignore
+
+// gen_factory generates the typed helpers of Factory (factory_types.go), from the table of types.
+// Run using `go generate`
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+)
+
+// types is the table of error types for which the helpers are generated
+var types = []string{
+	"Internal",
+	"Validation",
+	"InputBody",
+	"Duplicate",
+	"Unauthenticated",
+	"Unauthorized",
+	"Empty",
+	"NotFound",
+	"MaximumAttempts",
+	"SubscriptionExpired",
+	"DownstreamDependencyTimedout",
+	"NotImplemented",
+	"ContextTimedout",
+	"ContextCancelled",
+}
+
+var tmpl = template.Must(template.New("factory_types").Parse(`// Code generated by gen_factory.go; DO NOT EDIT.
+
+package errors
+{{range .}}
+// {{.}} returns a new error of type Type{{.}}, using the configuration of the factory
+func (f *Factory) {{.}}(message string) *Error {
+	return f.newerr(nil, message, Type{{.}}, 3)
+}
+
+// {{.}}f returns a new error of type Type{{.}} with formatted message, using the configuration of the factory
+func (f *Factory) {{.}}f(format string, args ...any) *Error {
+	return f.newerrf(nil, Type{{.}}, 4, format, args...)
+}
+
+// {{.}}Err wraps the original error as Type{{.}}, using the configuration of the factory
+func (f *Factory) {{.}}Err(original error, message string) *Error {
+	return f.newerr(original, message, Type{{.}}, 3)
+}
+
+// {{.}}Errf wraps the original error as Type{{.}} with formatted message, using the configuration of the factory
+func (f *Factory) {{.}}Errf(original error, format string, args ...any) *Error {
+	return f.newerrf(original, Type{{.}}, 4, format, args...)
+}
+{{end}}`))
+
+func main() {
+	buff := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(buff, types); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buff.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("factory_types.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,136 @@
+package errors
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+const (
+	// AttrGraphQLPath is the attribute key whose value ([]any) is used as the path of the GraphQL error,
+	// instead of adding it to the extensions
+	AttrGraphQLPath = "graphql.path"
+	// AttrGraphQLLocations is the attribute key whose value ([]GraphQLLocation) is used as the
+	// locations of the GraphQL error, instead of adding it to the extensions
+	AttrGraphQLLocations = "graphql.locations"
+)
+
+// GraphQLLocation is the location in the GraphQL document associated with an error
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError is an error as defined by the GraphQL spec. Its fields are the same as that of
+// gqlgen's gqlerror.Error
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// Error implements the error interface
+func (ge *GraphQLError) Error() string {
+	return ge.Message
+}
+
+// ToGraphQL converts the error to GraphQL errors. Joined errors produce one GraphQL error each.
+// Each is converted using the outermost *Error in its chain, i.e. skipping the errors wrapping it
+// (e.g. gqlgen's gqlerror.Error, or fmt.Errorf). The message is the user friendly message,
+// excluding those of TypeInternal errors and of all the errors wrapped by them, to not expose
+// internal details; DefaultMessage if there's none. extensions.code is the code of the error if
+// set, or the error type in screaming snake case (e.g. NOT_FOUND); and the attributes of the error
+// (not of the ones it wraps, nor of TypeInternal errors) are added to the extensions.
+func ToGraphQL(err error) []*GraphQLError {
+	if err == nil {
+		return nil
+	}
+
+	if je, ok := err.(interface{ Unwrap() []error }); ok {
+		list := make([]*GraphQLError, 0, len(je.Unwrap()))
+		for _, inner := range je.Unwrap() {
+			list = append(list, ToGraphQL(inner)...)
+		}
+		return list
+	}
+
+	return []*GraphQLError{graphQLError(err)}
+}
+
+// GraphQLPresenter has the same signature as gqlgen's ErrorPresenterFunc, except for the return type.
+// It can be used as follows:
+//
+//	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+//		gerr := graphql.DefaultErrorPresenter(ctx, err)
+//		presented := errors.GraphQLPresenter(ctx, err)
+//		gerr.Message, gerr.Extensions = presented.Message, presented.Extensions
+//		return gerr
+//	})
+//
+// For joined errors, only the first one is returned; use ToGraphQL to get all of them
+func GraphQLPresenter(ctx context.Context, err error) *GraphQLError {
+	list := ToGraphQL(err)
+	if len(list) == 0 {
+		return &GraphQLError{Message: DefaultMessage}
+	}
+	return list[0]
+}
+
+func graphQLError(err error) *GraphQLError {
+	ge := &GraphQLError{Message: DefaultMessage}
+	extensions := map[string]any{}
+
+	derr := outermostError(err)
+	if derr == nil {
+		extensions["code"] = screamingSnake(getErrType(err).String())
+		ge.Extensions = extensions
+		return ge
+	}
+
+	if msg := userMessage(derr, true); msg != "" {
+		ge.Message = msg
+	}
+
+	for _, attr := range derr.attrs {
+		switch attr.Key {
+		case AttrGraphQLPath:
+			ge.Path, _ = attr.Value.([]any)
+			continue
+		case AttrGraphQLLocations:
+			ge.Locations, _ = attr.Value.([]GraphQLLocation)
+			continue
+		}
+		// like the message, the attributes of internal errors are not exposed
+		if derr.eType == TypeInternal || isDropped(attr.Value) {
+			continue
+		}
+		extensions[attr.Key] = attr.Value
+	}
+
+	code := derr.code
+	if code == "" {
+		code = screamingSnake(derr.eType.String())
+	}
+	extensions["code"] = code
+	ge.Extensions = extensions
+
+	return ge
+}
+
+// screamingSnake converts a name in pascal case to screaming snake case, e.g. NotFound to NOT_FOUND
+func screamingSnake(name string) string {
+	buff := strings.Builder{}
+	runes := []rune(name)
+	for idx, r := range runes {
+		// a word starts at an upper case letter following a lower case one, or at the last upper
+		// case letter of an acronym, e.g. "HTTPError" is HTTP_ERROR
+		startsWord := unicode.IsUpper(r) && (!unicode.IsUpper(runes[max(idx-1, 0)]) ||
+			(idx+1 < len(runes) && unicode.IsLower(runes[idx+1])))
+		if idx > 0 && startsWord {
+			buff.WriteRune('_')
+		}
+		buff.WriteRune(unicode.ToUpper(r))
+	}
+	return buff.String()
+}
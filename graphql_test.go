@@ -0,0 +1,137 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestToGraphQL(t *testing.T) {
+	notFound := NotFound("user not found")
+	notFound.attrs = []Attr{
+		Attribute("user_id", 10),
+		Attribute(AttrGraphQLPath, []any{"user", 0, "name"}),
+		Attribute(AttrGraphQLLocations, []GraphQLLocation{{Line: 2, Column: 3}}),
+	}
+	quota := NewWithCode("QUOTA_EXCEEDED", "too many requests", TypeMaximumAttempts)
+	internal := InternalErr(errors.New("connection refused"), "database is down")
+	wrapsInternal := NotFoundErr(InternalErr(errors.New("connection refused"), "db password=x"), "user not found")
+	wrapsForeign := NotFoundErr(errors.New("secret foreign"), "")
+
+	list := ToGraphQL(Join(notFound, quota, internal, errors.New("secret"), wrapsInternal, wrapsForeign))
+	if len(list) != 6 {
+		t.Fatalf("ToGraphQL() returned %d errors, want 6", len(list))
+	}
+
+	tests := []struct {
+		name    string
+		got     *GraphQLError
+		message string
+		code    string
+	}{
+		{name: "type code", got: list[0], message: "user not found", code: "NOT_FOUND"},
+		{name: "code", got: list[1], message: "too many requests", code: "QUOTA_EXCEEDED"},
+		{name: "internal", got: list[2], message: DefaultMessage, code: "INTERNAL"},
+		{name: "foreign", got: list[3], message: DefaultMessage, code: "INTERNAL"},
+		{name: "wraps internal", got: list[4], message: "user not found", code: "NOT_FOUND"},
+		{name: "wraps foreign", got: list[5], message: DefaultMessage, code: "NOT_FOUND"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Message != tt.message {
+				t.Errorf("Message = %q, want %q", tt.got.Message, tt.message)
+			}
+			if tt.got.Extensions["code"] != tt.code {
+				t.Errorf("extensions.code = %v, want %q", tt.got.Extensions["code"], tt.code)
+			}
+		})
+	}
+
+	raw, err := json.Marshal(list[0])
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"message":"user not found","locations":[{"line":2,"column":3}],"path":["user",0,"name"],"extensions":{"code":"NOT_FOUND","user_id":10}}`
+	if string(raw) != want {
+		t.Errorf("json.Marshal() = %s\nwant %s", raw, want)
+	}
+
+	if got := ToGraphQL(nil); got != nil {
+		t.Errorf("ToGraphQL(nil) = %v, want nil", got)
+	}
+}
+
+func TestGraphQLPresenter(t *testing.T) {
+	got := GraphQLPresenter(context.Background(), Wrap(Validation("invalid email"), "signup"))
+	if got.Message != "signup: invalid email" || got.Extensions["code"] != "VALIDATION" {
+		t.Errorf("GraphQLPresenter() = %+v", got)
+	}
+	if got.Error() != got.Message {
+		t.Errorf("Error() = %q, want %q", got.Error(), got.Message)
+	}
+
+	got = GraphQLPresenter(context.Background(), Join(Unauthenticated("login required"), NotFound("x")))
+	if got.Message != "login required" || got.Extensions["code"] != "UNAUTHENTICATED" {
+		t.Errorf("GraphQLPresenter() = %+v", got)
+	}
+}
+
+// gqlWrapper wraps errors like gqlgen's gqlerror.Error does for every resolver error
+type gqlWrapper struct {
+	message string
+	err     error
+}
+
+func (gw *gqlWrapper) Error() string {
+	return gw.message
+}
+
+func (gw *gqlWrapper) Unwrap() error {
+	return gw.err
+}
+
+func TestGraphQLWrapped(t *testing.T) {
+	notFound := Build().Type(TypeNotFound).Attr("user_id", 10).New("user not found")
+	internal := Build().Type(TypeInternal).Attr("db_host", "10.0.0.1").New("db password=x")
+
+	tests := []struct {
+		name       string
+		err        error
+		message    string
+		code       string
+		extensions int
+	}{
+		{name: "foreign wrapped", err: fmt.Errorf("resolve user: %w", notFound), message: "user not found", code: "NOT_FOUND", extensions: 2},
+		{name: "gqlerror wrapped", err: &gqlWrapper{message: "input: user", err: notFound}, message: "user not found", code: "NOT_FOUND", extensions: 2},
+		{name: "internal attributes", err: &gqlWrapper{message: "input: user", err: internal}, message: DefaultMessage, code: "INTERNAL", extensions: 1},
+		{name: "wraps internal", err: NotFoundErr(internal, "user not found"), message: "user not found", code: "NOT_FOUND", extensions: 1},
+		{name: "foreign", err: &gqlWrapper{message: "input: user", err: errors.New("secret")}, message: DefaultMessage, code: "INTERNAL", extensions: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GraphQLPresenter(context.Background(), tt.err)
+			if got.Message != tt.message || got.Extensions["code"] != tt.code {
+				t.Errorf("GraphQLPresenter() = %+v, want message %q, code %q", got, tt.message, tt.code)
+			}
+			if len(got.Extensions) != tt.extensions || got.Extensions["db_host"] != nil {
+				t.Errorf("GraphQLPresenter().Extensions = %v", got.Extensions)
+			}
+		})
+	}
+}
+
+func TestScreamingSnake(t *testing.T) {
+	tests := map[string]string{
+		"Internal":                     "INTERNAL",
+		"NotFound":                     "NOT_FOUND",
+		"DownstreamDependencyTimedout": "DOWNSTREAM_DEPENDENCY_TIMEDOUT",
+		"HTTPError":                    "HTTP_ERROR",
+	}
+	for name, want := range tests {
+		if got := screamingSnake(name); got != want {
+			t.Errorf("screamingSnake(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
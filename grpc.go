@@ -73,28 +73,5 @@ func GRPCStatusCodeMessage(err error) (codes.Code, string, bool) {
 // are of type *Error
 // In case of joined errors, it'll return the status code of the last *Error
 func GRPCStatusCode(err error) (codes.Code, bool) {
-	derr, _ := err.(*Error)
-	if derr != nil {
-		return grpcStatusCode(derr.Type()), true
-	}
-
-	// Since TypeInternal is the default returned by getErrType, it is ignored.
-	if et := getErrType(err); et != TypeInternal {
-		return grpcStatusCode(et), false
-	}
-
-	jerr, _ := err.(*joinError)
-	if jerr != nil {
-		elen := len(jerr.errs)
-		isErr := true
-		for i := elen - 1; i >= 0; i-- {
-			code, isE := GRPCStatusCode(jerr.errs[i])
-			isErr = isE && isErr
-			if isE {
-				return code, isErr
-			}
-		}
-	}
-
-	return codes.Unknown, false
+	return Default().GRPCStatusCode(err)
 }
@@ -1,46 +1,13 @@
 package errors
 
 import (
-	"context"
 	"errors"
-	"fmt"
 	"net/http"
-	"runtime"
 	"strings"
 )
 
-func newerr(e error, message string, etype errType, skip int) *Error {
-	pcs := make([]uintptr, 128)
-	_ = runtime.Callers(skip, pcs)
-	return &Error{
-		original: e,
-		message:  message,
-		eType:    etype,
-		pcs:      pcs,
-		pc:       pcs[0] - 1,
-	}
-}
-
-func newerrf(e error, etype errType, skip int, format string, args ...any) *Error {
-	message := fmt.Sprintf(format, args...)
-	return newerr(e, message, etype, skip)
-}
-
 func getErrType(err error) errType {
-	e, _ := err.(*Error)
-	if e != nil {
-		return e.Type()
-	}
-
-	if errors.Is(err, context.DeadlineExceeded) {
-		return TypeContextTimedout
-	}
-
-	if errors.Is(err, context.Canceled) {
-		return TypeContextCancelled
-	}
-
-	return TypeInternal
+	return Default().errType(err)
 }
 
 // Wrap is used to simply wrap an error with optional message; error type would be the
@@ -48,307 +15,317 @@ func getErrType(err error) errType {
 // If the error being wrapped is already of type Error, then its respective type is used
 func Wrap(original error, msg ...string) *Error {
 	message := strings.Join(msg, ". ")
-	return newerr(original, message, getErrType(original), 3)
+	return Build().newerr(original, message, 3)
 }
 
 func Wrapf(original error, format string, args ...any) *Error {
-	return newerrf(original, getErrType(original), 4, format, args...)
+	return Build().newerrf(original, 4, format, args...)
 }
 
 // Deprecated: WrapWithMsg [deprecated, use `Wrap`] wrap error with a user friendly message
 func WrapWithMsg(original error, msg string) *Error {
-	return newerr(original, msg, getErrType(original), 3)
+	return Build().newerr(original, msg, 3)
 }
 
 // NewWithType returns an error instance with custom error type
 func NewWithType(msg string, etype errType) *Error {
-	return newerr(nil, msg, etype, 3)
+	return Build().Type(etype).newerr(nil, msg, 3)
 }
 
 // NewWithTypef returns an error instance with custom error type. And formatted message
 func NewWithTypef(etype errType, format string, args ...any) *Error {
-	return newerrf(nil, etype, 4, format, args...)
+	return Build().Type(etype).newerrf(nil, 4, format, args...)
 }
 
 // NewWithErrMsgType returns an error instance with custom error type and message
 func NewWithErrMsgType(original error, message string, etype errType) *Error {
-	return newerr(original, message, etype, 3)
+	return Build().Type(etype).newerr(original, message, 3)
 }
 
 // NewWithErrMsgTypef returns an error instance with custom error type and formatted message
 func NewWithErrMsgTypef(original error, etype errType, format string, args ...any) *Error {
-	return newerrf(original, etype, 4, format, args...)
+	return Build().Type(etype).newerrf(original, 4, format, args...)
+}
+
+// NewWithCode returns an error instance with custom error type and an application specific code
+func NewWithCode(code string, msg string, etype errType) *Error {
+	return Build().Type(etype).Code(code).newerr(nil, msg, 3)
+}
+
+// WrapWithCode is same as Wrap, and sets an application specific code for the error
+func WrapWithCode(original error, code string, msg ...string) *Error {
+	return Build().Code(code).newerr(original, strings.Join(msg, ". "), 3)
 }
 
 // Internal helper method for creating internal errors
 func Internal(message string) *Error {
-	return newerr(nil, message, TypeInternal, 3)
+	return Build().Type(TypeInternal).newerr(nil, message, 3)
 }
 
 // Internalf helper method for creating internal errors with formatted message
 func Internalf(format string, args ...any) *Error {
-	return newerrf(nil, TypeInternal, 4, format, args...)
+	return Build().Type(TypeInternal).newerrf(nil, 4, format, args...)
 }
 
 // Validation is a helper function to create a new error of type TypeValidation
 func Validation(message string) *Error {
-	return newerr(nil, message, TypeValidation, 3)
+	return Build().Type(TypeValidation).newerr(nil, message, 3)
 }
 
 // Validationf is a helper function to create a new error of type TypeValidation, with formatted message
 func Validationf(format string, args ...any) *Error {
-	return newerrf(nil, TypeValidation, 4, format, args...)
+	return Build().Type(TypeValidation).newerrf(nil, 4, format, args...)
 }
 
 // InputBody is a helper function to create a new error of type TypeInputBody
 func InputBody(message string) *Error {
-	return newerr(nil, message, TypeInputBody, 3)
+	return Build().Type(TypeInputBody).newerr(nil, message, 3)
 }
 
 // InputBodyf is a helper function to create a new error of type TypeInputBody, with formatted message
 func InputBodyf(format string, args ...any) *Error {
-	return newerrf(nil, TypeInputBody, 4, format, args...)
+	return Build().Type(TypeInputBody).newerrf(nil, 4, format, args...)
 }
 
 // Duplicate is a helper function to create a new error of type TypeDuplicate
 func Duplicate(message string) *Error {
-	return newerr(nil, message, TypeDuplicate, 3)
+	return Build().Type(TypeDuplicate).newerr(nil, message, 3)
 }
 
 // Duplicatef is a helper function to create a new error of type TypeDuplicate, with formatted message
 func Duplicatef(format string, args ...any) *Error {
-	return newerrf(nil, TypeDuplicate, 4, format, args...)
+	return Build().Type(TypeDuplicate).newerrf(nil, 4, format, args...)
 }
 
 // Unauthenticated is a helper function to create a new error of type TypeUnauthenticated
 func Unauthenticated(message string) *Error {
-	return newerr(nil, message, TypeUnauthenticated, 3)
+	return Build().Type(TypeUnauthenticated).newerr(nil, message, 3)
 }
 
 // Unauthenticatedf is a helper function to create a new error of type TypeUnauthenticated, with formatted message
 func Unauthenticatedf(format string, args ...any) *Error {
-	return newerrf(nil, TypeUnauthenticated, 4, format, args...)
+	return Build().Type(TypeUnauthenticated).newerrf(nil, 4, format, args...)
 
 }
 
 // Unauthorized is a helper function to create a new error of type TypeUnauthorized
 func Unauthorized(message string) *Error {
-	return newerr(nil, message, TypeUnauthorized, 3)
+	return Build().Type(TypeUnauthorized).newerr(nil, message, 3)
 }
 
 // Unauthorizedf is a helper function to create a new error of type TypeUnauthorized, with formatted message
 func Unauthorizedf(format string, args ...any) *Error {
-	return newerrf(nil, TypeUnauthorized, 4, format, args...)
+	return Build().Type(TypeUnauthorized).newerrf(nil, 4, format, args...)
 }
 
 // Empty is a helper function to create a new error of type TypeEmpty
 func Empty(message string) *Error {
-	return newerr(nil, message, TypeEmpty, 3)
+	return Build().Type(TypeEmpty).newerr(nil, message, 3)
 }
 
 // Emptyf is a helper function to create a new error of type TypeEmpty, with formatted message
 func Emptyf(format string, args ...any) *Error {
-	return newerrf(nil, TypeEmpty, 4, format, args...)
+	return Build().Type(TypeEmpty).newerrf(nil, 4, format, args...)
 }
 
 // NotFound is a helper function to create a new error of type TypeNotFound
 func NotFound(message string) *Error {
-	return newerr(nil, message, TypeNotFound, 3)
+	return Build().Type(TypeNotFound).newerr(nil, message, 3)
 }
 
 // NotFoundf is a helper function to create a new error of type TypeNotFound, with formatted message
 func NotFoundf(format string, args ...any) *Error {
-	return newerrf(nil, TypeNotFound, 4, format, args...)
+	return Build().Type(TypeNotFound).newerrf(nil, 4, format, args...)
 }
 
 // MaximumAttempts is a helper function to create a new error of type TypeMaximumAttempts
 func MaximumAttempts(message string) *Error {
-	return newerr(nil, message, TypeMaximumAttempts, 3)
+	return Build().Type(TypeMaximumAttempts).newerr(nil, message, 3)
 }
 
 // MaximumAttemptsf is a helper function to create a new error of type TypeMaximumAttempts, with formatted message
 func MaximumAttemptsf(format string, args ...any) *Error {
-	return newerrf(nil, TypeMaximumAttempts, 4, format, args...)
+	return Build().Type(TypeMaximumAttempts).newerrf(nil, 4, format, args...)
 }
 
 // SubscriptionExpired is a helper function to create a new error of type TypeSubscriptionExpired
 func SubscriptionExpired(message string) *Error {
-	return newerr(nil, message, TypeSubscriptionExpired, 3)
+	return Build().Type(TypeSubscriptionExpired).newerr(nil, message, 3)
 }
 
 // SubscriptionExpiredf is a helper function to create a new error of type TypeSubscriptionExpired, with formatted message
 func SubscriptionExpiredf(format string, args ...any) *Error {
-	return newerrf(nil, TypeSubscriptionExpired, 4, format, args...)
+	return Build().Type(TypeSubscriptionExpired).newerrf(nil, 4, format, args...)
 }
 
 // DownstreamDependencyTimedout is a helper function to create a new error of type TypeDownstreamDependencyTimedout
 func DownstreamDependencyTimedout(message string) *Error {
-	return newerr(nil, message, TypeDownstreamDependencyTimedout, 3)
+	return Build().Type(TypeDownstreamDependencyTimedout).newerr(nil, message, 3)
 }
 
 // DownstreamDependencyTimedoutf is a helper function to create a new error of type TypeDownstreamDependencyTimedout, with formatted message
 func DownstreamDependencyTimedoutf(format string, args ...any) *Error {
-	return newerrf(nil, TypeDownstreamDependencyTimedout, 4, format, args...)
+	return Build().Type(TypeDownstreamDependencyTimedout).newerrf(nil, 4, format, args...)
 }
 
 // NotImplemented is a helper function to create a new error of type TypeNotImplemented
 func NotImplemented(message string) *Error {
-	return newerr(nil, message, TypeNotImplemented, 3)
+	return Build().Type(TypeNotImplemented).newerr(nil, message, 3)
 }
 
 // NotImplementedf is a helper function to create a new error of type TypeNotImplemented, with formatted message
 func NotImplementedf(format string, args ...any) *Error {
-	return newerrf(nil, TypeNotImplemented, 4, format, args...)
+	return Build().Type(TypeNotImplemented).newerrf(nil, 4, format, args...)
 }
 
 // ContextCancelled is a helper function to create a new error of type TypeContextCancelled
 func ContextCancelled(message string) *Error {
-	return newerr(nil, message, TypeContextCancelled, 3)
+	return Build().Type(TypeContextCancelled).newerr(nil, message, 3)
 }
 
 // ContextCancelledf is a helper function to create a new error of type TypeContextCancelled, with formatted message
 func ContextCancelledf(format string, args ...any) *Error {
-	return newerrf(nil, TypeContextCancelled, 4, format, args...)
+	return Build().Type(TypeContextCancelled).newerrf(nil, 4, format, args...)
 }
 
 // TypeContextTimedout is a helper function to create a new error of type TypeContextTimedout
 func ContextTimedout(message string) *Error {
-	return newerr(nil, message, TypeContextTimedout, 3)
+	return Build().Type(TypeContextTimedout).newerr(nil, message, 3)
 }
 
 // ContextTimedoutf is a helper function to create a new error of type TypeContextTimedout, with formatted message
 func ContextTimedoutf(format string, args ...any) *Error {
-	return newerrf(nil, TypeContextTimedout, 4, format, args...)
+	return Build().Type(TypeContextTimedout).newerrf(nil, 4, format, args...)
 }
 
 // InternalErr helper method for creation internal errors which also accepts an original error
 func InternalErr(original error, message string) *Error {
-	return newerr(original, message, TypeInternal, 3)
+	return Build().Type(TypeInternal).newerr(original, message, 3)
 }
 
 // InternalErr helper method for creation internal errors which also accepts an original error, with formatted message
 func InternalErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeInternal, 4, format, args...)
+	return Build().Type(TypeInternal).newerrf(original, 4, format, args...)
 }
 
 // ValidationErr helper method for creation validation errors which also accepts an original error
 func ValidationErr(original error, message string) *Error {
-	return newerr(original, message, TypeValidation, 3)
+	return Build().Type(TypeValidation).newerr(original, message, 3)
 }
 
 // ValidationErr helper method for creation validation errors which also accepts an original error, with formatted message
 func ValidationErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeValidation, 4, format, args...)
+	return Build().Type(TypeValidation).newerrf(original, 4, format, args...)
 }
 
 // InputBodyErr is a helper function to create a new error of type TypeInputBody which also accepts an original error
 func InputBodyErr(original error, message string) *Error {
-	return newerr(original, message, TypeInputBody, 3)
+	return Build().Type(TypeInputBody).newerr(original, message, 3)
 }
 
 // InputBodyErrf is a helper function to create a new error of type TypeInputBody which also accepts an original error, with formatted message
 func InputBodyErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeInputBody, 4, format, args...)
+	return Build().Type(TypeInputBody).newerrf(original, 4, format, args...)
 }
 
 // DuplicateErr is a helper function to create a new error of type TypeDuplicate which also accepts an original error
 func DuplicateErr(original error, message string) *Error {
-	return newerr(original, message, TypeDuplicate, 3)
+	return Build().Type(TypeDuplicate).newerr(original, message, 3)
 }
 
 // DuplicateErrf is a helper function to create a new error of type TypeDuplicate which also accepts an original error, with formatted message
 func DuplicateErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeDuplicate, 4, format, args...)
+	return Build().Type(TypeDuplicate).newerrf(original, 4, format, args...)
 }
 
 // UnauthenticatedErr is a helper function to create a new error of type TypeUnauthenticated which also accepts an original error
 func UnauthenticatedErr(original error, message string) *Error {
-	return newerr(original, message, TypeUnauthenticated, 3)
+	return Build().Type(TypeUnauthenticated).newerr(original, message, 3)
 }
 
 // UnauthenticatedErrf is a helper function to create a new error of type TypeUnauthenticated which also accepts an original error, with formatted message
 func UnauthenticatedErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeUnauthenticated, 4, format, args...)
+	return Build().Type(TypeUnauthenticated).newerrf(original, 4, format, args...)
 }
 
 // UnauthorizedErr is a helper function to create a new error of type TypeUnauthorized which also accepts an original error
 func UnauthorizedErr(original error, message string) *Error {
-	return newerr(original, message, TypeUnauthorized, 3)
+	return Build().Type(TypeUnauthorized).newerr(original, message, 3)
 }
 
 // UnauthorizedErrf is a helper function to create a new error of type TypeUnauthorized which also accepts an original error, with formatted message
 func UnauthorizedErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeUnauthorized, 4, format, args...)
+	return Build().Type(TypeUnauthorized).newerrf(original, 4, format, args...)
 }
 
 // EmptyErr is a helper function to create a new error of type TypeEmpty which also accepts an original error
 func EmptyErr(original error, message string) *Error {
-	return newerr(original, message, TypeEmpty, 3)
+	return Build().Type(TypeEmpty).newerr(original, message, 3)
 }
 
 // EmptyErr is a helper function to create a new error of type TypeEmpty which also accepts an original error, with formatted message
 func EmptyErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeEmpty, 4, format, args...)
+	return Build().Type(TypeEmpty).newerrf(original, 4, format, args...)
 }
 
 // NotFoundErr is a helper function to create a new error of type TypeNotFound which also accepts an original error
 func NotFoundErr(original error, message string) *Error {
-	return newerr(original, message, TypeNotFound, 3)
+	return Build().Type(TypeNotFound).newerr(original, message, 3)
 }
 
 // NotFoundErrf is a helper function to create a new error of type TypeNotFound which also accepts an original error, with formatted message
 func NotFoundErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeNotFound, 4, format, args...)
+	return Build().Type(TypeNotFound).newerrf(original, 4, format, args...)
 }
 
 // MaximumAttemptsErr is a helper function to create a new error of type TypeMaximumAttempts which also accepts an original error
 func MaximumAttemptsErr(original error, message string) *Error {
-	return newerr(original, message, TypeMaximumAttempts, 3)
+	return Build().Type(TypeMaximumAttempts).newerr(original, message, 3)
 }
 
 // MaximumAttemptsErr is a helper function to create a new error of type TypeMaximumAttempts which also accepts an original error, with formatted message
 func MaximumAttemptsErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeMaximumAttempts, 4, format, args...)
+	return Build().Type(TypeMaximumAttempts).newerrf(original, 4, format, args...)
 }
 
 // SubscriptionExpiredErr is a helper function to create a new error of type TypeSubscriptionExpired which also accepts an original error
 func SubscriptionExpiredErr(original error, message string) *Error {
-	return newerr(original, message, TypeSubscriptionExpired, 3)
+	return Build().Type(TypeSubscriptionExpired).newerr(original, message, 3)
 }
 
 // SubscriptionExpiredErrf is a helper function to create a new error of type TypeSubscriptionExpired which also accepts an original error, with formatted message
 func SubscriptionExpiredErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeSubscriptionExpired, 4, format, args...)
+	return Build().Type(TypeSubscriptionExpired).newerrf(original, 4, format, args...)
 }
 
 // DownstreamDependencyTimedoutErr is a helper function to create a new error of type TypeDownstreamDependencyTimedout which also accepts an original error
 func DownstreamDependencyTimedoutErr(original error, message string) *Error {
-	return newerr(original, message, TypeDownstreamDependencyTimedout, 3)
+	return Build().Type(TypeDownstreamDependencyTimedout).newerr(original, message, 3)
 }
 
 // DownstreamDependencyTimedoutErrf is a helper function to create a new error of type TypeDownstreamDependencyTimedout which also accepts an original error, with formatted message
 func DownstreamDependencyTimedoutErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeDownstreamDependencyTimedout, 4, format, args...)
+	return Build().Type(TypeDownstreamDependencyTimedout).newerrf(original, 4, format, args...)
 }
 
 // NotImplementedErr is a helper function to create a new error of type TypeNotImplemented which also accepts an original error
 func NotImplementedErr(original error, message string) *Error {
-	return newerr(original, message, TypeNotImplemented, 3)
+	return Build().Type(TypeNotImplemented).newerr(original, message, 3)
 }
 
 // NotImplementedErrf is a helper function to create a new error of type TypeNotImplemented which also accepts an original error, with formatted message
 func NotImplementedErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeNotImplemented, 4, format, args...)
+	return Build().Type(TypeNotImplemented).newerrf(original, 4, format, args...)
 }
 
 // ContextCancelledErr is a helper function to create a new error of type TypeContextCancelled which also accepts an original error
 func ContextCancelledErr(original error, message string) *Error {
-	return newerr(original, message, TypeContextCancelled, 3)
+	return Build().Type(TypeContextCancelled).newerr(original, message, 3)
 }
 
 // ContextCancelledErrf is a helper function to create a new error of type TypeContextCancelled which also accepts an original error, with formatted message
 func ContextCancelledErrf(original error, format string, args ...any) *Error {
-	return newerrf(original, TypeContextCancelled, 4, format, args...)
+	return Build().Type(TypeContextCancelled).newerrf(original, 4, format, args...)
 }
 
 // ErrWithoutTrace is a duplicate of Message, but with clearer name. The boolean is 'true' if the
@@ -360,7 +337,7 @@ func ErrWithoutTrace(err error) (string, bool) {
 // Message recursively concatenates all the messages set while creating/wrapping the errors. The boolean
 // is 'true' if the provided error is of type *Err
 func Message(err error) (string, bool) {
-	derr, _ := err.(*Error)
+	derr, _ := asError(err)
 	if derr != nil {
 		return derr.Message(), true
 	}
@@ -383,6 +360,44 @@ func Message(err error) (string, bool) {
 	return "", false
 }
 
+// outermostError returns the outermost *Error in the chain of err, skipping the errors which wrap
+// it (e.g. fmt.Errorf("...: %w", err)); nil if there's none. Joined errors are not traversed
+func outermostError(err error) *Error {
+	for ; err != nil; err = Unwrap(err) {
+		if e, ok := asError(err); ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// userMessage is same as Message, except that it never falls back to Error(), and returns an empty
+// string if there's no message. If public is true, the walk stops at the first *Error of type
+// TypeInternal, so that neither its message nor those of the errors it wraps are included
+func userMessage(err error, public bool) string {
+	jerr, _ := err.(*joinError)
+	if jerr != nil {
+		list := make([]string, 0, len(jerr.errs))
+		for i := range jerr.errs {
+			if msg := userMessage(jerr.errs[i], public); msg != "" {
+				list = append(list, msg)
+			}
+		}
+		return strings.Join(list, "\n")
+	}
+
+	messages := make([]string, 0, 5)
+	for derr, _ := asError(err); derr != nil; derr, _ = asError(derr.original) {
+		if public && derr.Type() == TypeInternal {
+			break
+		}
+		if message := derr.text(); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return strings.Join(messages, ": ")
+}
+
 // WriteHTTP is a convenience method which will check if the error is of type *Error and
 // respond appropriately
 func WriteHTTP(err error, w http.ResponseWriter) {
@@ -392,10 +407,34 @@ func WriteHTTP(err error, w http.ResponseWriter) {
 	_, _ = w.Write([]byte(msg))
 }
 
+// Code returns the code of the outermost *Error in the chain which has a code set, empty string otherwise
+func Code(err error) string {
+	code := ""
+	walk(err, func(e *Error) bool {
+		code = e.code
+		return code == ""
+	})
+	return code
+}
+
+// Find returns the first *Error in the error tree, including joined errors, for which match returns
+// true. e.g. Find(err, func(e *Error) bool { return e.Code() == "quota_exceeded" })
+func Find(err error, match func(e *Error) bool) *Error {
+	var found *Error
+	walk(err, func(e *Error) bool {
+		if match(e) {
+			found = e
+			return false
+		}
+		return true
+	})
+	return found
+}
+
 // Type returns the errType if it's an instance of *Error, -1 otherwise
 // In case of joined error, it'll return the type of the last *Error
 func Type(err error) errType {
-	e, _ := err.(*Error)
+	e, _ := asError(err)
 	if e != nil {
 		return e.Type()
 	}
@@ -423,7 +462,7 @@ func HasType(err error, et errType) bool {
 		return false
 	}
 
-	e, _ := err.(*Error)
+	e, _ := asError(err)
 	if e == nil {
 		return HasType(errors.Unwrap(err), et)
 	}
@@ -31,7 +31,7 @@ func TestWrap(t *testing.T) {
 	want = Error{
 		original: err,
 		message:  message,
-		eType:    defaultErrType,
+		eType:    Default().config.DefaultType,
 	}
 	e = Wrap(err, message)
 	e.pcs = nil
@@ -48,6 +48,7 @@ func TestWrapf(t *testing.T) {
 		original: err,
 		message:  fmt.Sprintf(format, message),
 		eType:    TypeInternal,
+		format:   format,
 	}
 	e := Wrapf(err, format, message)
 	e.pcs = nil
@@ -62,7 +63,8 @@ func TestWrapf(t *testing.T) {
 	want = Error{
 		original: err,
 		message:  fmt.Sprintf(format, message),
-		eType:    defaultErrType,
+		eType:    Default().config.DefaultType,
+		format:   format,
 	}
 	e = Wrapf(err, format, message)
 	e.pcs = nil
@@ -276,3 +278,60 @@ func TestContextErrors(t *testing.T) {
 		t.Errorf("expected %d/%s, got: %d", codes.DeadlineExceeded, codes.DeadlineExceeded, code)
 	}
 }
+
+func TestFind(t *testing.T) {
+	quota := NewWithCode("quota_exceeded", "too many requests", TypeMaximumAttempts)
+	tenant := InternalErr(quota, "tenant")
+	tenant.attrs = []Attr{Attribute("tenant", "acme")}
+	err := Wrap(Join(NotFound("user not found"), tenant), "get user")
+
+	tests := []struct {
+		name  string
+		match func(e *Error) bool
+		want  *Error
+	}{
+		{
+			name:  "type",
+			match: func(e *Error) bool { return e.Type() == TypeMaximumAttempts },
+			want:  quota,
+		},
+		{
+			name:  "code",
+			match: func(e *Error) bool { return e.Code() == "quota_exceeded" },
+			want:  quota,
+		},
+		{
+			name: "attribute",
+			match: func(e *Error) bool {
+				for _, attr := range e.Attributes() {
+					if attr.Key == "tenant" {
+						return true
+					}
+				}
+				return false
+			},
+			want: tenant,
+		},
+		{
+			name:  "outermost first",
+			match: func(e *Error) bool { return e.Type() == TypeInternal },
+			want:  err,
+		},
+		{
+			name:  "no match",
+			match: func(e *Error) bool { return e.Type() == TypeUnauthorized },
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Find(err, tt.match); got != tt.want {
+				t.Errorf("Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Find(nil, func(*Error) bool { return true }); got != nil {
+		t.Errorf("Find(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Hook is called for every *Error as soon as it is created (or wrapped). caller is the frame where
+// the error was created. The returned attributes are attached to the error.
+// Hooks are called synchronously, so they should be cheap, e.g. incrementing a counter or sampling
+type Hook func(e *Error, caller Frame) []Attr
+
+type hookEntry struct {
+	fn Hook
+}
+
+var (
+	hooksMu = sync.Mutex{}
+	hooks   = atomic.Pointer[[]*hookEntry]{}
+)
+
+// RegisterHook registers a hook which is called for all errors created using this package, and
+// returns a function to unregister it
+func RegisterHook(fn Hook) (unregister func()) {
+	entry := &hookEntry{fn: fn}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	list := []*hookEntry{}
+	if existing := hooks.Load(); existing != nil {
+		list = append(list, *existing...)
+	}
+	list = append(list, entry)
+	hooks.Store(&list)
+
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+		existing := hooks.Load()
+		if existing == nil {
+			return
+		}
+		list := make([]*hookEntry, 0, len(*existing))
+		for _, e := range *existing {
+			if e != entry {
+				list = append(list, e)
+			}
+		}
+		if len(list) == 0 {
+			hooks.Store(nil)
+			return
+		}
+		hooks.Store(&list)
+	}
+}
+
+// runHooks calls the hooks of the factory followed by the registered ones
+func (f *Factory) runHooks(e *Error) {
+	registered := hooks.Load()
+	if len(f.config.Hooks) == 0 && registered == nil {
+		return
+	}
+
+	frames := runtime.CallersFrames([]uintptr{e.pc + 1})
+	rf, _ := frames.Next()
+	caller := newFrame(rf, e.text(), e)
+
+	for _, fn := range f.config.Hooks {
+		e.attrs = append(e.attrs, fn(e, caller)...)
+	}
+	if registered == nil {
+		return
+	}
+	for _, entry := range *registered {
+		e.attrs = append(e.attrs, entry.fn(e, caller)...)
+	}
+}
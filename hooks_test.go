@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRegisterHook(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		callers []Frame
+		wrapped int
+	)
+	unregister := RegisterHook(func(e *Error, caller Frame) []Attr {
+		mu.Lock()
+		defer mu.Unlock()
+		callers = append(callers, caller)
+		if e.Unwrap() != nil {
+			wrapped++
+		}
+		return []Attr{Attribute("hooked", true)}
+	})
+
+	err := Wrapf(NotFound("user not found"), "get user %d", 10)
+	unregister()
+	_ = New("after unregister")
+
+	if len(callers) != 2 || wrapped != 1 {
+		t.Fatalf("hook called %d times (%d wrapped), want 2 (1 wrapped)", len(callers), wrapped)
+	}
+	for _, caller := range callers {
+		if caller.ShortFile() != "hooks_test.go" || caller.Function != "github.com/naughtygopher/errors.TestRegisterHook" {
+			t.Errorf("unexpected caller %s %s:%d", caller.Function, caller.File, caller.Line)
+		}
+	}
+	if callers[0].Message != "user not found" || callers[1].Message != "get user 10" {
+		t.Errorf("unexpected messages %q, %q", callers[0].Message, callers[1].Message)
+	}
+	if got := err.Attributes(); len(got) != 1 || got[0].Key != "hooked" {
+		t.Errorf("Attributes() = %v", got)
+	}
+	if hooks.Load() != nil {
+		t.Error("hooks should be empty after unregistering")
+	}
+}
+
+func TestFactoryHooks(t *testing.T) {
+	order := []string{}
+	f := NewFactory(Config{
+		Hooks: []Hook{
+			func(e *Error, caller Frame) []Attr {
+				order = append(order, "factory")
+				return []Attr{Attribute("factory", true)}
+			},
+		},
+	})
+	unregister := RegisterHook(func(e *Error, caller Frame) []Attr {
+		order = append(order, "registered")
+		return nil
+	})
+	defer unregister()
+
+	err := f.Wrap(errors.New("oops"), "wrapped")
+	if strings.Join(order, ",") != "factory,registered" {
+		t.Errorf("hooks called in order %v", order)
+	}
+	if got := err.Attributes(); len(got) != 1 || got[0].Key != "factory" {
+		t.Errorf("Attributes() = %v", got)
+	}
+}
+
+func TestRegisterHookConcurrent(t *testing.T) {
+	count := atomic.Int64{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unregister := RegisterHook(func(*Error, Frame) []Attr {
+				count.Add(1)
+				return nil
+			})
+			_ = Internal("hello")
+			unregister()
+		}()
+	}
+	wg.Wait()
+
+	if count.Load() < 8 {
+		t.Errorf("hooks called %d times, want at least 8", count.Load())
+	}
+}
+
+func TestHookSeesCompleteError(t *testing.T) {
+	type seen struct {
+		code    string
+		payload any
+		msgKey  string
+		attrs   int
+	}
+	var got seen
+	unregister := RegisterHook(func(e *Error, caller Frame) []Attr {
+		got = seen{code: e.Code(), payload: e.payload, msgKey: e.MessageKey(), attrs: len(e.Attributes())}
+		return nil
+	})
+	t.Cleanup(unregister)
+	t.Cleanup(RegisterContextExtractor(ContextValue(ctxKey("request_id"), "request_id")))
+
+	_ = NewWithCode("USR404", "user not found", TypeNotFound)
+	if got.code != "USR404" {
+		t.Errorf("hook code = %q, want %q", got.code, "USR404")
+	}
+
+	_ = WrapWithPayload(errors.New("exists"), 42, "user exists")
+	if got.payload != 42 {
+		t.Errorf("hook payload = %v, want %v", got.payload, 42)
+	}
+
+	_ = NewLocalized(TypeNotFound, "user.not_found", "user %d not found", 1)
+	if got.msgKey != "user.not_found" {
+		t.Errorf("hook message key = %q, want %q", got.msgKey, "user.not_found")
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey("request_id"), "req-1")
+	_ = WrapCtx(ctx, errors.New("timeout"), "get user")
+	if got.attrs != 1 {
+		t.Errorf("hook attributes = %d, want the context attributes", got.attrs)
+	}
+}
@@ -72,28 +72,5 @@ func HTTPStatusCodeMessage(err error) (int, string, bool) {
 // are of type *Error
 // In case of joined errors, it'll return the status code of the last *Error
 func HTTPStatusCode(err error) (int, bool) {
-	derr, _ := err.(*Error)
-	if derr != nil {
-		return httpStatusCode(derr.Type()), true
-	}
-
-	// Since TypeInternal is the default returned by getErrType, it is ignored.
-	if et := getErrType(err); et != TypeInternal {
-		return httpStatusCode(et), false
-	}
-
-	jerr, _ := err.(*joinError)
-	if jerr != nil {
-		elen := len(jerr.errs)
-		isErr := true
-		for i := elen - 1; i >= 0; i-- {
-			code, isE := HTTPStatusCode(jerr.errs[i])
-			isErr = isE && isErr
-			if isE {
-				return code, isErr
-			}
-		}
-	}
-
-	return http.StatusInternalServerError, false
+	return Default().HTTPStatusCode(err)
 }
@@ -0,0 +1,193 @@
+package errors
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// JSON-RPC 2.0 pre-defined error codes
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+	// JSONRPCServerErrorMax & JSONRPCServerErrorMin are the bounds of the codes reserved for
+	// implementation defined server errors
+	JSONRPCServerErrorMax = -32000
+	JSONRPCServerErrorMin = -32099
+)
+
+// JSONRPCApplicationErrorBase is the start of the application defined codes. Error types which do
+// not have an equivalent pre-defined code are mapped to JSONRPCApplicationErrorBase + type, e.g. 1007
+// for TypeNotFound, which is outside of the range -32768 to -32000 reserved by the specification
+const JSONRPCApplicationErrorBase = 1000
+
+// JSONRPCError is the error object of a JSON-RPC 2.0 response
+type JSONRPCError struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *JSONRPCErrorData `json:"data,omitempty"`
+}
+
+// JSONRPCErrorData is the additional information of a JSONRPCError
+type JSONRPCErrorData struct {
+	// Type is the name of the error type, e.g. "NotFound"
+	Type string `json:"type,omitempty"`
+	// Code is the application specific code of the error
+	Code       string         `json:"code,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// Error implements the error interface
+func (je *JSONRPCError) Error() string {
+	return je.Message
+}
+
+// JSONRPCCode returns the JSON-RPC error code for the error type
+func JSONRPCCode(et errType) int {
+	switch et {
+	case TypeInternal:
+		return JSONRPCInternalError
+	case TypeInputBody:
+		return JSONRPCInvalidRequest
+	case TypeValidation:
+		return JSONRPCInvalidParams
+	case TypeNotImplemented:
+		return JSONRPCMethodNotFound
+	}
+
+	if _, ok := typeName(et); !ok {
+		return JSONRPCInternalError
+	}
+	return JSONRPCApplicationErrorBase + et.Int()
+}
+
+// JSONRPCType returns the error type for the JSON-RPC error code, i.e. the reverse of JSONRPCCode.
+// Unknown codes are TypeInternal
+func JSONRPCType(code int) errType {
+	switch code {
+	case JSONRPCInternalError:
+		return TypeInternal
+	case JSONRPCParseError, JSONRPCInvalidRequest:
+		return TypeInputBody
+	case JSONRPCInvalidParams:
+		return TypeValidation
+	case JSONRPCMethodNotFound:
+		return TypeNotImplemented
+	}
+
+	if code < JSONRPCApplicationErrorBase {
+		return TypeInternal
+	}
+	et := errType(code - JSONRPCApplicationErrorBase)
+	if _, ok := typeName(et); !ok {
+		return TypeInternal
+	}
+	return et
+}
+
+// ToJSONRPC converts the error to a JSON-RPC error object. The message is the user friendly message,
+// excluding those of TypeInternal errors and of all the errors wrapped by them, to not expose
+// internal details; DefaultMessage if there's none. The type, code & attributes of the outermost
+// *Error are added to data, excluding those of TypeInternal errors. In case of joined errors, the
+// last *Error is converted
+func ToJSONRPC(err error) *JSONRPCError {
+	if err == nil {
+		return nil
+	}
+
+	jerr, _ := err.(*joinError)
+	if jerr != nil {
+		for i := len(jerr.errs) - 1; i >= 0; i-- {
+			if Type(jerr.errs[i]).Int() != -1 {
+				return ToJSONRPC(jerr.errs[i])
+			}
+		}
+	}
+
+	derr := outermostError(err)
+	if derr == nil {
+		return &JSONRPCError{
+			Code:    JSONRPCInternalError,
+			Message: DefaultMessage,
+			Data:    &JSONRPCErrorData{Type: TypeInternal.String()},
+		}
+	}
+
+	et := derr.eType
+	msg := userMessage(derr, true)
+	if msg == "" {
+		msg = DefaultMessage
+	}
+
+	data := &JSONRPCErrorData{Type: et.String()}
+	lookup := map[string]struct{}{}
+	walk(derr, func(e *Error) bool {
+		// like the message, the code & attributes of internal errors are not exposed
+		if e.eType == TypeInternal {
+			return false
+		}
+		if data.Code == "" {
+			data.Code = e.code
+		}
+		for _, attr := range e.attrs {
+			if _, ok := lookup[attr.Key]; ok || isDropped(attr.Value) {
+				continue
+			}
+			lookup[attr.Key] = struct{}{}
+			if data.Attributes == nil {
+				data.Attributes = map[string]any{}
+			}
+			data.Attributes[attr.Key] = attr.Value
+		}
+		return true
+	})
+
+	return &JSONRPCError{
+		Code:    JSONRPCCode(et),
+		Message: msg,
+		Data:    data,
+	}
+}
+
+// FromJSONRPC reconstructs an *Error from the JSON-RPC error object, e.g. on the client side. The
+// type is determined from data.type if available, and from the JSON-RPC code otherwise. The
+// stacktrace is of the caller of FromJSONRPC
+func FromJSONRPC(je *JSONRPCError) *Error {
+	return fromJSONRPC(je, 4)
+}
+
+// DecodeJSONRPCError decodes the JSON-RPC error object and reconstructs an *Error, refer FromJSONRPC
+func DecodeJSONRPCError(data []byte) (*Error, error) {
+	je := &JSONRPCError{}
+	if err := json.Unmarshal(data, je); err != nil {
+		return nil, err
+	}
+	return fromJSONRPC(je, 4), nil
+}
+
+func fromJSONRPC(je *JSONRPCError, skip int) *Error {
+	if je == nil {
+		return nil
+	}
+
+	b := Build().Type(JSONRPCType(je.Code))
+	if je.Data != nil {
+		if et, err := ParseType(je.Data.Type); err == nil {
+			b = b.Type(et)
+		}
+		b = b.Code(je.Data.Code)
+
+		keys := make([]string, 0, len(je.Data.Attributes))
+		for key := range je.Data.Attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			b = b.Attr(key, je.Data.Attributes[key])
+		}
+	}
+
+	return b.newerr(nil, je.Message, skip)
+}
@@ -0,0 +1,153 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestJSONRPCCode(t *testing.T) {
+	custom, _ := RegisterType("JSONRPCCustom")
+	tests := []struct {
+		name  string
+		etype errType
+		code  int
+	}{
+		{name: "internal", etype: TypeInternal, code: JSONRPCInternalError},
+		{name: "input body", etype: TypeInputBody, code: JSONRPCInvalidRequest},
+		{name: "validation", etype: TypeValidation, code: JSONRPCInvalidParams},
+		{name: "not implemented", etype: TypeNotImplemented, code: JSONRPCMethodNotFound},
+		{name: "duplicate", etype: TypeDuplicate, code: 1003},
+		{name: "not found", etype: TypeNotFound, code: 1007},
+		{name: "context cancelled", etype: TypeContextCancelled, code: 1013},
+		{name: "registered", etype: custom, code: JSONRPCApplicationErrorBase + custom.Int()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JSONRPCCode(tt.etype); got != tt.code {
+				t.Errorf("JSONRPCCode() = %d, want %d", got, tt.code)
+			}
+			if got := JSONRPCType(tt.code); got != tt.etype {
+				t.Errorf("JSONRPCType() = %v, want %v", got, tt.etype)
+			}
+		})
+	}
+
+	for _, code := range []int{JSONRPCServerErrorMin, JSONRPCServerErrorMax, -31999, 0, 1, JSONRPCApplicationErrorBase - 1, JSONRPCApplicationErrorBase + 5000} {
+		if got := JSONRPCType(code); got != TypeInternal {
+			t.Errorf("JSONRPCType(%d) = %v, want %v", code, got, TypeInternal)
+		}
+	}
+	if got := JSONRPCType(JSONRPCParseError); got != TypeInputBody {
+		t.Errorf("JSONRPCType(%d) = %v, want %v", JSONRPCParseError, got, TypeInputBody)
+	}
+	// types above 99 must not collide with the codes of other types either
+	typesMu.Lock()
+	registered := len(typeNames)
+	for idx := registered; idx <= 150; idx++ {
+		typeNames = append(typeNames, fmt.Sprintf("JSONRPCCustom%d", idx))
+	}
+	typesMu.Unlock()
+	t.Cleanup(func() {
+		typesMu.Lock()
+		typeNames = typeNames[:registered]
+		typesMu.Unlock()
+	})
+	high := errType(150)
+	if got := JSONRPCType(JSONRPCCode(high)); got != high {
+		t.Errorf("JSONRPCType(JSONRPCCode(%d)) = %v, want %v", high.Int(), got, high)
+	}
+	if got := JSONRPCCode(errType(5000)); got != JSONRPCInternalError {
+		t.Errorf("JSONRPCCode() of out of range type = %d, want %d", got, JSONRPCInternalError)
+	}
+}
+
+func TestToJSONRPC(t *testing.T) {
+	err := Build().Type(TypeNotFound).Code("user_not_found").Attr("user_id", 10).New("user not found")
+	je := ToJSONRPC(Wrap(err, "get user"))
+
+	raw, jerr := json.Marshal(je)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+	want := `{"code":1007,"message":"get user: user not found","data":{"type":"NotFound","code":"user_not_found","attributes":{"user_id":10}}}`
+	if string(raw) != want {
+		t.Errorf("json.Marshal() = %s\nwant %s", raw, want)
+	}
+
+	tests := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{name: "foreign", err: errors.New("oops"), code: JSONRPCInternalError, message: DefaultMessage},
+		{name: "internal", err: InternalErr(errors.New("dial tcp"), "db password=x"), code: JSONRPCInternalError, message: DefaultMessage},
+		{name: "wraps internal", err: NotFoundErr(InternalErr(errors.New("dial tcp"), "db password=x"), "user not found"), code: 1007, message: "user not found"},
+		{name: "empty message", err: NotFound(""), code: 1007, message: DefaultMessage},
+		{name: "joined", err: Join(Validation("invalid email"), errors.New("oops"), NotFound("user not found")), code: 1007, message: "user not found"},
+		{name: "foreign wrapped", err: fmt.Errorf("get user: %w", NotFound("user not found")), code: 1007, message: "user not found"},
+		{name: "foreign wrapped internal", err: fmt.Errorf("get user: %w", Internal("db password=x")), code: JSONRPCInternalError, message: DefaultMessage},
+		{name: "joined foreign", err: Join(errors.New("oops"), errors.New("secret")), code: JSONRPCInternalError, message: DefaultMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToJSONRPC(tt.err)
+			if got.Code != tt.code || got.Message != tt.message {
+				t.Errorf("ToJSONRPC() = (%d, %q), want (%d, %q)", got.Code, got.Message, tt.code, tt.message)
+			}
+		})
+	}
+	internal := Build().Type(TypeInternal).Code("db_down").Attr("dsn", "password=x").Wrap(errors.New("dial tcp"), "db")
+	data := ToJSONRPC(fmt.Errorf("get user: %w", Build().Type(TypeNotFound).Attr("user_id", 10).Wrap(internal, "user not found"))).Data
+	if data.Type != "NotFound" || data.Code != "" || len(data.Attributes) != 1 || data.Attributes["user_id"] != 10 {
+		t.Errorf("ToJSONRPC().Data = %+v, want the type & attributes of NotFound only", data)
+	}
+	if got := ToJSONRPC(errors.New("oops")).Data.Type; got != "Internal" {
+		t.Errorf("ToJSONRPC().Data.Type = %q, want Internal", got)
+	}
+	if ToJSONRPC(nil) != nil {
+		t.Error("ToJSONRPC(nil) should be nil")
+	}
+}
+
+func TestDecodeJSONRPCError(t *testing.T) {
+	original := Build().Type(TypeDuplicate).Code("email_taken").Attr("email", "a@b.c").Attr("attempt", 2).New("email already exists")
+	raw, jerr := json.Marshal(ToJSONRPC(original))
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+
+	decoded, jerr := DecodeJSONRPCError(raw)
+	if jerr != nil {
+		t.Fatalf("DecodeJSONRPCError() error = %v", jerr)
+	}
+	if decoded.Type() != TypeDuplicate || decoded.Code() != "email_taken" || decoded.Message() != "email already exists" {
+		t.Errorf("decoded error = %v, type %v, code %q", decoded, decoded.Type(), decoded.Code())
+	}
+	attrs := decoded.Attributes()
+	if len(attrs) != 2 || attrs[0].Key != "attempt" || attrs[1].Key != "email" || attrs[1].Value != "a@b.c" {
+		t.Errorf("Attributes() = %v", attrs)
+	}
+	if !strings.Contains(decoded.Error(), "jsonrpc_test.go") {
+		t.Errorf("Error() = %q, expected the location of the caller", decoded.Error())
+	}
+
+	// without data, the type is determined by the code
+	decoded = FromJSONRPC(&JSONRPCError{Code: JSONRPCInvalidParams, Message: "invalid params"})
+	if decoded.Type() != TypeValidation || decoded.Message() != "invalid params" {
+		t.Errorf("FromJSONRPC() = %v, type %v", decoded, decoded.Type())
+	}
+	if !strings.Contains(decoded.Error(), "jsonrpc_test.go") {
+		t.Errorf("Error() = %q, expected the location of the caller", decoded.Error())
+	}
+
+	if _, jerr = DecodeJSONRPCError([]byte("{")); jerr == nil {
+		t.Error("DecodeJSONRPCError() expected error for invalid JSON")
+	}
+	if FromJSONRPC(nil) != nil {
+		t.Error("FromJSONRPC(nil) should be nil")
+	}
+}
@@ -0,0 +1,222 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Catalog provides the translated message formats
+type Catalog interface {
+	// Lookup returns the message format (as accepted by fmt.Sprintf) for the key, in the given locale
+	Lookup(locale string, key string) (string, bool)
+}
+
+// MapCatalog is a Catalog backed by a map of locale -> key -> message format
+type MapCatalog map[string]map[string]string
+
+// Lookup implements Catalog
+func (mc MapCatalog) Lookup(locale string, key string) (string, bool) {
+	format, ok := mc[locale][key]
+	return format, ok
+}
+
+// MissingTranslationFunc is called when a localized error does not have a translation in any of
+// the requested locales preferred over the default locale; locales are those missing ones
+type MissingTranslationFunc func(locales []string, key string)
+
+// Localization is the configuration used to resolve the localized messages
+type Localization struct {
+	Catalog Catalog
+	// DefaultLocale is the locale of the default messages provided while creating the errors, "en"
+	// if empty. Missing translations are not reported for the default locale
+	DefaultLocale string
+	// OnMissing is called when a translation is missing, optional
+	OnMissing MissingTranslationFunc
+}
+
+var localization = atomic.Pointer[Localization]{}
+
+// SetLocalization sets the configuration used by all the localized functions
+func SetLocalization(l Localization) {
+	if l.DefaultLocale == "" {
+		l.DefaultLocale = "en"
+	}
+	localization.Store(&l)
+}
+
+// NewLocalized returns a new error with a message key which is resolved against the Catalog. The
+// default message (in the DefaultLocale) is created using defaultFormat & args, and is used by
+// Message(), Error() etc.
+func NewLocalized(etype errType, key string, defaultFormat string, args ...any) *Error {
+	return Build().Type(etype).localized(key, args).newerrf(nil, 4, defaultFormat, args...)
+}
+
+// WrapLocalized is same as NewLocalized, and wraps the original error
+func WrapLocalized(original error, key string, defaultFormat string, args ...any) *Error {
+	return Build().localized(key, args).newerrf(original, 4, defaultFormat, args...)
+}
+
+// MessageKey returns the message key of a localized error
+func (e *Error) MessageKey() string {
+	return e.msgKey
+}
+
+// localizedMessage returns the message of the error, translated to the first available locale
+func (e *Error) localizedMessage(cfg *Localization, locales []string) string {
+	if e.msgKey == "" || cfg == nil || cfg.Catalog == nil {
+		return e.text()
+	}
+
+	for idx, locale := range locales {
+		if isLocale(locale, cfg.DefaultLocale) {
+			// the preferred locales before the default one are missing all the same
+			locales = locales[:idx]
+			break
+		}
+		if format, ok := lookupLocale(cfg.Catalog, locale, e.msgKey); ok {
+			return fmt.Sprintf(format, e.msgArgs...)
+		}
+	}
+
+	if cfg.OnMissing != nil && len(locales) > 0 {
+		cfg.OnMissing(locales, e.msgKey)
+	}
+
+	return e.text()
+}
+
+// lookupLocale looks up the key in the locale, and falls back to the base language. e.g. pt-BR -> pt
+func lookupLocale(catalog Catalog, locale string, key string) (string, bool) {
+	format, ok := catalog.Lookup(locale, key)
+	if ok {
+		return format, true
+	}
+	if idx := strings.IndexAny(locale, "-_"); idx > 0 {
+		return catalog.Lookup(locale[:idx], key)
+	}
+	return "", false
+}
+
+func isLocale(locale string, target string) bool {
+	if strings.EqualFold(locale, target) {
+		return true
+	}
+	idx := strings.IndexAny(locale, "-_")
+	return idx > 0 && strings.EqualFold(locale[:idx], target)
+}
+
+// LocalizedMessage is same as Message, but the messages are translated to the first available
+// locale among the provided ones (in order of preference). The default message is used if there's
+// no translation available.
+func LocalizedMessage(err error, locales ...string) (string, bool) {
+	return localizedMessage(localization.Load(), err, locales)
+}
+
+func localizedMessage(cfg *Localization, err error, locales []string) (string, bool) {
+	derr, _ := asError(err)
+	if derr != nil {
+		messages := make([]string, 0, 5)
+		for e := derr; e != nil; e, _ = asError(e.original) {
+			if msg := e.localizedMessage(cfg, locales); msg != "" {
+				messages = append(messages, msg)
+			}
+		}
+		if len(messages) == 0 {
+			return derr.Error(), true
+		}
+		return strings.Join(messages, ": "), true
+	}
+
+	jerr, _ := err.(*joinError)
+	if jerr != nil {
+		list := make([]string, 0, len(jerr.errs))
+		isErr := true
+		for i := range jerr.errs {
+			msg, ok := localizedMessage(cfg, jerr.errs[i], locales)
+			isErr = isErr && ok
+			if msg == "" {
+				continue
+			}
+			list = append(list, msg)
+		}
+		return strings.Join(list, "\n"), isErr
+	}
+
+	return "", false
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header value, in the order of preference
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	list := make([]weighted, 0, 4)
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+			q = parsed
+		}
+		list = append(list, weighted{locale: locale, q: q})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].q > list[j].q
+	})
+
+	locales := make([]string, 0, len(list))
+	for _, w := range list {
+		locales = append(locales, w.locale)
+	}
+
+	return locales
+}
+
+// WriteHTTPLocalized is same as WriteHTTP, but the message is translated based on the Accept-Language
+// header of the request
+func WriteHTTPLocalized(err error, w http.ResponseWriter, r *http.Request) {
+	status, _ := HTTPStatusCode(err)
+	msg, _ := LocalizedMessage(err, ParseAcceptLanguage(r.Header.Get("Accept-Language"))...)
+	if msg == "" {
+		msg = err.Error()
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(msg))
+}
+
+// GRPCStatusCodeMessageLocalized is same as GRPCStatusCodeMessage, but the message is translated based on
+// the 'accept-language' metadata of the incoming context
+func GRPCStatusCodeMessageLocalized(ctx context.Context, err error) (codes.Code, string, bool) {
+	code, isErr := GRPCStatusCode(err)
+
+	locales := []string{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("accept-language") {
+		locales = append(locales, ParseAcceptLanguage(value)...)
+	}
+
+	msg, isErrMsg := LocalizedMessage(err, locales...)
+	if msg == "" {
+		msg = err.Error()
+	}
+	return code, msg, isErr && isErrMsg
+}
@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func setTestLocalization(t *testing.T, missing *[]string) {
+	t.Helper()
+	before := localization.Load()
+	t.Cleanup(func() { localization.Store(before) })
+
+	SetLocalization(Localization{
+		Catalog: MapCatalog{
+			"de": {
+				"user.not_found": "Benutzer %d nicht gefunden",
+			},
+			"pt": {
+				"user.not_found": "Usuário %d não encontrado",
+				"user.get":       "falha ao buscar usuário",
+			},
+		},
+		OnMissing: func(locales []string, key string) {
+			*missing = append(*missing, key)
+		},
+	})
+}
+
+func TestLocalizedMessage(t *testing.T) {
+	missing := []string{}
+	setTestLocalization(t, &missing)
+
+	err := WrapLocalized(NewLocalized(TypeNotFound, "user.not_found", "user %d not found", 42), "user.get", "failed to get user")
+	if err.Type() != TypeNotFound {
+		t.Errorf("Type() = %v, want %v", err.Type(), TypeNotFound)
+	}
+	if err.Message() != "failed to get user: user 42 not found" {
+		t.Errorf("Message() = %q", err.Message())
+	}
+
+	tests := []struct {
+		name    string
+		locales []string
+		want    string
+	}{
+		{name: "no locale", want: "failed to get user: user 42 not found"},
+		{name: "default locale", locales: []string{"en-US", "de"}, want: "failed to get user: user 42 not found"},
+		{name: "base language fallback", locales: []string{"pt-BR"}, want: "falha ao buscar usuário: Usuário 42 não encontrado"},
+		{name: "partial translation", locales: []string{"de"}, want: "failed to get user: Benutzer 42 nicht gefunden"},
+		{name: "preference order", locales: []string{"fr", "de", "pt"}, want: "falha ao buscar usuário: Benutzer 42 nicht gefunden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LocalizedMessage(err, tt.locales...)
+			if !ok || got != tt.want {
+				t.Errorf("LocalizedMessage() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+
+	want := []string{"user.get"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing translations = %v, want %v", missing, want)
+	}
+
+	joined := Join(err, Validation("plain"))
+	got, ok := LocalizedMessage(joined, "de")
+	if !ok || got != "failed to get user: Benutzer 42 nicht gefunden\nplain" {
+		t.Errorf("LocalizedMessage() = %q, %v", got, ok)
+	}
+}
+
+func TestLocalizedMessageMissingBeforeDefault(t *testing.T) {
+	before := localization.Load()
+	t.Cleanup(func() { localization.Store(before) })
+
+	reported := [][]string{}
+	SetLocalization(Localization{
+		Catalog: MapCatalog{"de": {"user.not_found": "Benutzer %d nicht gefunden"}},
+		OnMissing: func(locales []string, key string) {
+			reported = append(reported, append([]string{key}, locales...))
+		},
+	})
+
+	err := NewLocalized(TypeNotFound, "user.not_found", "user %d not found", 42)
+	for _, locales := range [][]string{{"fr", "en"}, {"fr", "pt-BR", "en-US", "de"}, {"en", "fr"}, {"fr", "de"}} {
+		if got, _ := LocalizedMessage(err, locales...); got == "" {
+			t.Errorf("LocalizedMessage(%v) is empty", locales)
+		}
+	}
+
+	want := [][]string{{"user.not_found", "fr"}, {"user.not_found", "fr", "pt-BR"}}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("missing translations = %v, want %v", reported, want)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string][]string{
+		"":                                   {},
+		"de":                                 {"de"},
+		"fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5": {"fr-CH", "fr", "en"},
+		"en;q=0.1, pt-BR":                    {"pt-BR", "en"},
+		"en;q=0, de;q=abc, it":               {"it"},
+	}
+	for header, want := range tests {
+		if got := ParseAcceptLanguage(header); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestWriteHTTPLocalized(t *testing.T) {
+	missing := []string{}
+	setTestLocalization(t, &missing)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+	rec := httptest.NewRecorder()
+	WriteHTTPLocalized(NewLocalized(TypeNotFound, "user.not_found", "user %d not found", 1), rec, req)
+	if rec.Code != http.StatusNotFound || rec.Body.String() != "Benutzer 1 nicht gefunden" {
+		t.Errorf("WriteHTTPLocalized() = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGRPCStatusCodeMessageLocalized(t *testing.T) {
+	missing := []string{}
+	setTestLocalization(t, &missing)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "pt"))
+	code, msg, ok := GRPCStatusCodeMessageLocalized(ctx, NewLocalized(TypeNotFound, "user.not_found", "user %d not found", 1))
+	if code != codes.NotFound || msg != "Usuário 1 não encontrado" || !ok {
+		t.Errorf("GRPCStatusCodeMessageLocalized() = %v, %q, %v", code, msg, ok)
+	}
+}
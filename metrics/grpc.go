@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a GRPC interceptor which observes the errors returned by the handlers
+func (c *Collector) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		resp, err := handler(ctx, req)
+		c.Observe(err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a GRPC interceptor which observes the errors returned by the handlers
+func (c *Collector) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		err := handler(srv, ss)
+		c.Observe(err)
+		return err
+	}
+}
@@ -0,0 +1,220 @@
+// Package metrics counts errors by type, HTTP/GRPC status code and fingerprint. The counters are
+// exposed as a prometheus.Collector, as well as using expvar (dependency free). It is a separate
+// module, so that the core module does not depend on Prometheus
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/naughtygopher/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// DefaultName is the default name of the counter
+	DefaultName = "errors_total"
+	// DefaultMaxFingerprints is the default maximum number of unique fingerprints tracked
+	DefaultMaxFingerprints = 100
+	// Overflow is the label value used for fingerprint and origin, once the maximum number of
+	// unique fingerprints are tracked
+	Overflow = "other"
+)
+
+var labelNames = []string{"type", "http_status", "grpc_code", "fingerprint", "origin"}
+
+// Labels are the labels of a single counter
+type Labels struct {
+	// Type is the name of the error type, or "" for errors not of type *errors.Error
+	Type string
+	// HTTPStatus is the HTTP status code as returned by errors.HTTPStatusCode
+	HTTPStatus int
+	// GRPCCode is the GRPC status code as returned by errors.GRPCStatusCode
+	GRPCCode codes.Code
+	// Fingerprint is the fingerprint of the error as returned by errors.Fingerprint
+	Fingerprint string
+	// Origin is the function where the innermost *errors.Error was created
+	Origin string
+}
+
+func (l Labels) values() []string {
+	return []string{l.Type, strconv.Itoa(l.HTTPStatus), l.GRPCCode.String(), l.Fingerprint, l.Origin}
+}
+
+// Sample is the value of a single counter
+type Sample struct {
+	Labels Labels
+	Count  uint64
+}
+
+// Collector counts the errors observed. It implements prometheus.Collector
+type Collector struct {
+	name            string
+	help            string
+	maxFingerprints int
+
+	desc         *prometheus.Desc
+	mu           sync.Mutex
+	counts       map[Labels]uint64
+	fingerprints map[string]struct{}
+}
+
+// Option is used to customize the Collector
+type Option func(*Collector)
+
+// WithName sets the name of the counter, DefaultName otherwise
+func WithName(name string) Option {
+	return func(c *Collector) {
+		c.name = name
+	}
+}
+
+// WithHelp sets the help text of the counter
+func WithHelp(help string) Option {
+	return func(c *Collector) {
+		c.help = help
+	}
+}
+
+// WithMaxFingerprints sets the maximum number of unique fingerprints tracked, to keep the cardinality
+// bounded. Once the limit is reached, errors with new fingerprints are counted with fingerprint
+// and origin set to Overflow
+func WithMaxFingerprints(max int) Option {
+	return func(c *Collector) {
+		c.maxFingerprints = max
+	}
+}
+
+// New returns a new Collector
+func New(opts ...Option) *Collector {
+	c := &Collector{
+		name:            DefaultName,
+		help:            "Total number of errors, by type, HTTP status, GRPC code and fingerprint",
+		maxFingerprints: DefaultMaxFingerprints,
+		counts:          map[Labels]uint64{},
+		fingerprints:    map[string]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.desc = prometheus.NewDesc(c.name, c.help, labelNames, nil)
+
+	return c
+}
+
+// Observe increments the counter for the error. Nil errors are ignored
+func (c *Collector) Observe(err error) {
+	if err == nil {
+		return
+	}
+
+	labels := Labels{
+		Fingerprint: errors.Fingerprint(err),
+		Origin:      origin(err),
+	}
+	labels.HTTPStatus, _ = errors.HTTPStatusCode(err)
+	labels.GRPCCode, _ = errors.GRPCStatusCode(err)
+	if et := errors.Type(err); et.Int() != -1 {
+		labels.Type = et.String()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.fingerprints[labels.Fingerprint]; !ok {
+		if len(c.fingerprints) >= c.maxFingerprints {
+			labels.Fingerprint = Overflow
+			labels.Origin = Overflow
+		} else {
+			c.fingerprints[labels.Fingerprint] = struct{}{}
+		}
+	}
+	c.counts[labels]++
+}
+
+// Samples returns the current value of all the counters, sorted by their labels
+func (c *Collector) Samples() []Sample {
+	c.mu.Lock()
+	samples := make([]Sample, 0, len(c.counts))
+	for labels, count := range c.counts {
+		samples = append(samples, Sample{Labels: labels, Count: count})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool {
+		return strings.Join(samples[i].Labels.values(), "|") < strings.Join(samples[j].Labels.values(), "|")
+	})
+
+	return samples
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, sample := range c.Samples() {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc,
+			prometheus.CounterValue,
+			float64(sample.Count),
+			sample.Labels.values()...,
+		)
+	}
+}
+
+// Expvar returns the counters as an expvar.Var. The value is a JSON object, where the keys are
+// the label values joined by '|', in the order type, http_status, grpc_code, fingerprint, origin
+func (c *Collector) Expvar() expvar.Var {
+	return expvar.Func(func() any {
+		samples := c.Samples()
+		counts := make(map[string]uint64, len(samples))
+		for _, sample := range samples {
+			counts[strings.Join(sample.Labels.values(), "|")] = sample.Count
+		}
+		return counts
+	})
+}
+
+// Publish publishes the counters using expvar, with the given name
+func (c *Collector) Publish(name string) {
+	expvar.Publish(name, c.Expvar())
+}
+
+// HandlerFunc is an HTTP handler which returns an error
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Handler returns an http.Handler which observes the error returned by fn, and responds using
+// errors.WriteHTTP
+func (c *Collector) Handler(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := fn(w, r)
+		if err == nil {
+			return
+		}
+		c.Observe(err)
+		errors.WriteHTTP(err, w)
+	})
+}
+
+// origin returns the function where the innermost *errors.Error was created. The error tree,
+// including joined errors, is traversed the same way as errors.Fingerprint, so that the origin is
+// of the same *errors.Error as the fingerprint
+func origin(err error) string {
+	var inner *errors.Error
+	_ = errors.Find(err, func(e *errors.Error) bool {
+		inner = e
+		return false
+	})
+	if inner == nil {
+		return ""
+	}
+	frame, _ := inner.RuntimeFrames().Next()
+	return frame.Function
+}
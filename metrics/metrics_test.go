@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/naughtygopher/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func notFound(id int) error {
+	return errors.NotFoundf("user %d not found", id)
+}
+
+func TestObserve(t *testing.T) {
+	c := New()
+	for id := range 3 {
+		c.Observe(notFound(id))
+	}
+	c.Observe(fmt.Errorf("std error"))
+	c.Observe(nil)
+
+	samples := c.Samples()
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d: %v", len(samples), samples)
+	}
+
+	var found bool
+	for _, sample := range samples {
+		if sample.Labels.Type != "NotFound" {
+			continue
+		}
+		found = true
+		if sample.Count != 3 {
+			t.Errorf("Count = %d, want 3", sample.Count)
+		}
+		if sample.Labels.HTTPStatus != http.StatusNotFound || sample.Labels.GRPCCode != codes.NotFound {
+			t.Errorf("unexpected labels %+v", sample.Labels)
+		}
+		if !strings.HasSuffix(sample.Labels.Origin, "metrics.notFound") {
+			t.Errorf("Origin = %q", sample.Labels.Origin)
+		}
+		if sample.Labels.Fingerprint == "" || sample.Labels.Fingerprint == Overflow {
+			t.Errorf("Fingerprint = %q", sample.Labels.Fingerprint)
+		}
+	}
+	if !found {
+		t.Errorf("NotFound sample missing: %v", samples)
+	}
+}
+
+func TestObserveJoined(t *testing.T) {
+	c := New()
+	c.Observe(errors.Join(fmt.Errorf("std error"), errors.Wrap(notFound(1), "get user")))
+
+	samples := c.Samples()
+	if len(samples) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(samples))
+	}
+	if got := samples[0].Labels.Origin; !strings.HasSuffix(got, ".notFound") {
+		t.Errorf("Origin = %q, want suffix %q", got, ".notFound")
+	}
+}
+
+func TestMaxFingerprints(t *testing.T) {
+	c := New(WithMaxFingerprints(1))
+	c.Observe(errors.Validation("first"))
+	c.Observe(errors.Validation("second"))
+	c.Observe(errors.Validation("third"))
+
+	samples := c.Samples()
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d: %v", len(samples), samples)
+	}
+	overflow := 0
+	for _, sample := range samples {
+		if sample.Labels.Fingerprint == Overflow {
+			overflow++
+			if sample.Count != 2 || sample.Labels.Origin != Overflow {
+				t.Errorf("unexpected overflow sample %+v", sample)
+			}
+		}
+	}
+	if overflow != 1 {
+		t.Errorf("expected 1 overflow sample, got %d", overflow)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := New(WithName("app_errors_total"))
+	c.Observe(errors.Internal("db unreachable"))
+
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(c); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if got := testutil.CollectAndCount(c, "app_errors_total"); got != 1 {
+		t.Errorf("CollectAndCount() = %d, want 1", got)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	metric := families[0].GetMetric()[0]
+	if metric.GetCounter().GetValue() != 1 {
+		t.Errorf("counter = %v, want 1", metric.GetCounter().GetValue())
+	}
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	if labels["type"] != "Internal" || labels["http_status"] != "500" || labels["grpc_code"] != "Internal" {
+		t.Errorf("unexpected labels %v", labels)
+	}
+}
+
+func TestExpvar(t *testing.T) {
+	c := New()
+	err := errors.Duplicate("already exists")
+	c.Observe(err)
+
+	counts := map[string]uint64{}
+	if jerr := json.Unmarshal([]byte(c.Expvar().String()), &counts); jerr != nil {
+		t.Fatalf("json.Unmarshal() error = %v", jerr)
+	}
+	for key, count := range counts {
+		if !strings.HasPrefix(key, "Duplicate|409|AlreadyExists|"+errors.Fingerprint(err)) || count != 1 {
+			t.Errorf("unexpected expvar %s: %d", key, count)
+		}
+	}
+	if len(counts) != 1 {
+		t.Errorf("expected 1 expvar entry, got %v", counts)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := New()
+	handler := c.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Path == "/ok" {
+			return nil
+		}
+		return errors.Unauthenticated("login required")
+	})
+
+	for _, path := range []string{"/ok", "/fail"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if path == "/fail" && rec.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	}
+
+	samples := c.Samples()
+	if len(samples) != 1 || samples[0].Labels.Type != "Unauthenticated" {
+		t.Errorf("unexpected samples %v", samples)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	c := New()
+	_, err := c.UnaryServerInterceptor()(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(ctx context.Context, req any) (any, error) {
+			return nil, errors.Unauthorized("forbidden")
+		},
+	)
+	if err == nil {
+		t.Error("expected error from interceptor")
+	}
+
+	err = c.StreamServerInterceptor()(nil, nil, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+		return errors.Unauthorized("forbidden")
+	})
+	if err == nil {
+		t.Error("expected error from interceptor")
+	}
+
+	samples := c.Samples()
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples (different fingerprints), got %v", samples)
+	}
+	for _, sample := range samples {
+		if sample.Labels.GRPCCode != codes.PermissionDenied || sample.Count != 1 {
+			t.Errorf("unexpected sample %+v", sample)
+		}
+	}
+}
@@ -17,6 +17,38 @@ func As(err error, target interface{}) bool {
 	return errors.As(err, target)
 }
 
+// AsType is the generic variant of As, it returns the first error in the chain which is of type T
+func AsType[T error](err error) (T, bool) {
+	var target T
+	ok := errors.As(err, &target)
+	return target, ok
+}
+
+// AllAs returns all the errors of type T in the error tree, including the ones within joined
+// errors, in depth first order
+func AllAs[T error](err error) []T {
+	var list []T
+	for err != nil {
+		if e, ok := err.(T); ok {
+			list = append(list, e)
+		} else if e, ok := err.(interface{ As(any) bool }); ok {
+			var target T
+			if e.As(&target) {
+				list = append(list, target)
+			}
+		}
+
+		if je, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, inner := range je.Unwrap() {
+				list = append(list, AllAs[T](inner)...)
+			}
+			break
+		}
+		err = errors.Unwrap(err)
+	}
+	return list
+}
+
 func Join(errs ...error) error {
 	n := len(errs)
 	if n == 0 {
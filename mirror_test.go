@@ -171,3 +171,73 @@ func TestJoin(t *testing.T) {
 		)
 	}
 }
+
+type customErr struct {
+	msg string
+}
+
+func (c *customErr) Error() string {
+	return c.msg
+}
+
+func TestAsType(t *testing.T) {
+	custom := &customErr{msg: "custom"}
+	err := Wrap(fmt.Errorf("wrapped: %w", custom), "outer")
+
+	got, ok := AsType[*customErr](err)
+	if !ok || got != custom {
+		t.Errorf("AsType() = (%v, %v), want (%v, true)", got, ok, custom)
+	}
+
+	derr, ok := AsType[*Error](fmt.Errorf("std: %w", err))
+	if !ok || derr != err {
+		t.Errorf("AsType() = (%v, %v), want (%v, true)", derr, ok, err)
+	}
+
+	if got, ok := AsType[*customErr](New("hello")); ok || got != nil {
+		t.Errorf("AsType() = (%v, %v), want (nil, false)", got, ok)
+	}
+}
+
+func TestAllAs(t *testing.T) {
+	first := &customErr{msg: "first"}
+	second := &customErr{msg: "second"}
+	third := &customErr{msg: "third"}
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{name: "nil", err: nil, want: nil},
+		{name: "no match", err: New("hello"), want: nil},
+		{name: "single", err: Wrap(first, "outer"), want: []string{"first"}},
+		{
+			name: "joined",
+			err:  Wrap(Join(first, fmt.Errorf("std: %w", second)), "outer"),
+			want: []string{"first", "second"},
+		},
+		{
+			name: "std joined",
+			err:  errors.Join(Wrap(first), errors.Join(second, third)),
+			want: []string{"first", "second", "third"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := AllAs[*customErr](tt.err)
+			got := make([]string, 0, len(list))
+			for _, e := range list {
+				got = append(got, e.msg)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("AllAs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	all := AllAs[*Error](Wrap(Join(NotFound("a"), Validation("b")), "c"))
+	if len(all) != 3 {
+		t.Errorf("AllAs[*Error]() returned %d errors, want 3", len(all))
+	}
+}
@@ -0,0 +1,20 @@
+module github.com/naughtygopher/errors/otelerrors
+
+go 1.23.0
+
+require (
+	github.com/naughtygopher/errors v1.3.2-0.20261019074338-eec0d1f30ec7
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/sdk v1.37.0
+	go.opentelemetry.io/otel/trace v1.37.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.37.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	google.golang.org/grpc v1.73.0 // indirect
+)
@@ -0,0 +1,33 @@
+// Package otelerrors integrates github.com/naughtygopher/errors with OpenTelemetry. It is a separate
+// module, so that the core module does not depend on OpenTelemetry
+package otelerrors
+
+import (
+	"context"
+
+	"github.com/naughtygopher/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// AttrTraceID is the attribute key used for the trace ID
+	AttrTraceID = "trace_id"
+	// AttrSpanID is the attribute key used for the span ID
+	AttrSpanID = "span_id"
+)
+
+// SpanContext is an errors.ContextExtractor which attaches the trace ID & span ID of the span
+// in the context, if any. It can be registered using
+//
+//	errors.RegisterContextExtractor(otelerrors.SpanContext)
+func SpanContext(ctx context.Context) []errors.Attr {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []errors.Attr{
+		errors.Attribute(AttrTraceID, sc.TraceID().String()),
+		errors.Attribute(AttrSpanID, sc.SpanID().String()),
+	}
+}
@@ -0,0 +1,29 @@
+package otelerrors
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/naughtygopher/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanContext(t *testing.T) {
+	if got := SpanContext(context.Background()); got != nil {
+		t.Errorf("SpanContext() = %v, want nil", got)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	want := []errors.Attr{
+		errors.Attribute(AttrTraceID, "0102030405060708090a0b0c0d0e0f10"),
+		errors.Attribute(AttrSpanID, "0102030405060708"),
+	}
+	if got := SpanContext(ctx); !reflect.DeepEqual(got, want) {
+		t.Errorf("SpanContext() = %v, want %v", got, want)
+	}
+}
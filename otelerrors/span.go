@@ -0,0 +1,145 @@
+package otelerrors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/naughtygopher/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EventName is the name of the span event, as per OpenTelemetry semantic conventions
+	EventName = "exception"
+
+	attrExceptionType       = attribute.Key("exception.type")
+	attrExceptionMessage    = attribute.Key("exception.message")
+	attrExceptionStacktrace = attribute.Key("exception.stacktrace")
+)
+
+// StatusFunc returns the span status to be set for the error. If the returned code is
+// codes.Unset, the span status is not changed
+type StatusFunc func(err error) (codes.Code, string)
+
+type config struct {
+	status     StatusFunc
+	stacktrace bool
+}
+
+// Option is used to customize RecordError
+type Option func(*config)
+
+// WithStatus overrides the default StatusFunc (DefaultStatus)
+func WithStatus(fn StatusFunc) Option {
+	return func(c *config) {
+		c.status = fn
+	}
+}
+
+// WithoutStacktrace disables recording the stacktrace as 'exception.stacktrace'
+func WithoutStacktrace() Option {
+	return func(c *config) {
+		c.stacktrace = false
+	}
+}
+
+// DefaultStatus sets the span status based on the severity of the error if set; SeverityError &
+// SeverityFatal set it to Error, and lower severities leave it unset. Otherwise the status is set to
+// Error, only if the error would result in a 5xx HTTP status code. Errors of client side types
+// (e.g. TypeValidation, TypeNotFound) leave the status unset
+func DefaultStatus(err error) (codes.Code, string) {
+	switch severity := errors.SeverityOf(err); {
+	case severity == errors.SeverityUnset:
+		status, _ := errors.HTTPStatusCode(err)
+		if status < http.StatusInternalServerError {
+			return codes.Unset, ""
+		}
+	case severity < errors.SeverityError:
+		return codes.Unset, ""
+	}
+
+	msg, _ := errors.Message(err)
+	if msg == "" {
+		msg = err.Error()
+	}
+	return codes.Error, msg
+}
+
+// RecordError records the error as a span event using the OpenTelemetry semantic conventions for
+// exceptions. The error type name is recorded as 'exception.type', the user friendly message as
+// 'exception.message' and the stacktrace as 'exception.stacktrace'. Attributes of the error are
+// recorded as event attributes; sensitive ones are redacted, or omitted with errors.RedactDrop.
+// The span status is set based on the StatusFunc (DefaultStatus by default).
+func RecordError(span trace.Span, err error, opts ...Option) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	cfg := &config{
+		status:     DefaultStatus,
+		stacktrace: true,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	span.AddEvent(EventName, trace.WithAttributes(Attributes(err, cfg.stacktrace)...))
+
+	code, description := cfg.status(err)
+	if code != codes.Unset {
+		span.SetStatus(code, description)
+	}
+}
+
+// Attributes returns the OpenTelemetry attributes of the error, as recorded by RecordError
+func Attributes(err error, withStacktrace bool) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 8)
+
+	msg, isErr := errors.Message(err)
+	if isErr {
+		attrs = append(attrs, attrExceptionType.String(errors.Type(err).String()))
+	} else {
+		attrs = append(attrs, attrExceptionType.String(fmt.Sprintf("%T", err)))
+	}
+
+	if msg == "" {
+		msg = err.Error()
+	}
+	attrs = append(attrs, attrExceptionMessage.String(msg))
+
+	if withStacktrace {
+		attrs = append(attrs, attrExceptionStacktrace.String(errors.Stacktrace(err)))
+	}
+
+	for _, attr := range errors.Attributes(err) {
+		if attr.IsDropped() {
+			continue
+		}
+		attrs = append(attrs, keyValue(attr))
+	}
+
+	return attrs
+}
+
+func keyValue(attr errors.Attr) attribute.KeyValue {
+	key := attribute.Key(attr.Key)
+	switch value := attr.Value.(type) {
+	case string:
+		return key.String(value)
+	case bool:
+		return key.Bool(value)
+	case int:
+		return key.Int(value)
+	case int64:
+		return key.Int64(value)
+	case float64:
+		return key.Float64(value)
+	case []string:
+		return key.StringSlice(value)
+	case fmt.Stringer:
+		return key.String(value.String())
+	}
+	return key.String(fmt.Sprint(attr.Value))
+}
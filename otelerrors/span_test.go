@@ -0,0 +1,140 @@
+package otelerrors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/naughtygopher/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type userIDKey struct{}
+
+func recordSpan(t *testing.T, err error, opts ...Option) tracetest.SpanStub {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	_, span := provider.Tracer("test").Start(context.Background(), "handler")
+	RecordError(span, err, opts...)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	return spans[0]
+}
+
+func eventAttrs(t *testing.T, span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	t.Helper()
+	if len(span.Events) != 1 || span.Events[0].Name != EventName {
+		t.Fatalf("unexpected events %v", span.Events)
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range span.Events[0].Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestRecordError(t *testing.T) {
+	inner := errors.NotFound("user not found")
+	t.Cleanup(errors.RegisterContextExtractor(errors.ContextValue(userIDKey{}, "user_id")))
+	ctx := context.WithValue(context.Background(), userIDKey{}, 10)
+	err := errors.WrapCtx(ctx, inner, "get user")
+	span := recordSpan(t, err)
+
+	attrs := eventAttrs(t, span)
+	if got := attrs[attrExceptionType].AsString(); got != "NotFound" {
+		t.Errorf("exception.type = %q, want %q", got, "NotFound")
+	}
+	if got := attrs[attrExceptionMessage].AsString(); got != "get user: user not found" {
+		t.Errorf("exception.message = %q", got)
+	}
+	if got := attrs[attrExceptionStacktrace].AsString(); !strings.Contains(got, "span_test.go") {
+		t.Errorf("exception.stacktrace = %q", got)
+	}
+	if got := attrs["user_id"].AsInt64(); got != 10 {
+		t.Errorf("user_id = %d, want 10", got)
+	}
+	if span.Status.Code != codes.Unset {
+		t.Errorf("status = %v, want %v", span.Status.Code, codes.Unset)
+	}
+}
+
+func TestRecordErrorStatus(t *testing.T) {
+	span := recordSpan(t, errors.Internal("db unreachable"))
+	if span.Status.Code != codes.Error || span.Status.Description != "db unreachable" {
+		t.Errorf("status = %v, want %v", span.Status, codes.Error)
+	}
+
+	span = recordSpan(t, fmt.Errorf("std error"), WithoutStacktrace())
+	attrs := eventAttrs(t, span)
+	if got := attrs[attrExceptionType].AsString(); got != "*errors.errorString" {
+		t.Errorf("exception.type = %q", got)
+	}
+	if _, ok := attrs[attrExceptionStacktrace]; ok {
+		t.Error("exception.stacktrace should not be recorded")
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("status = %v, want %v", span.Status.Code, codes.Error)
+	}
+
+	span = recordSpan(t, errors.Internal("db unreachable"), WithStatus(func(error) (codes.Code, string) {
+		return codes.Unset, ""
+	}))
+	if span.Status.Code != codes.Unset {
+		t.Errorf("status = %v, want %v", span.Status.Code, codes.Unset)
+	}
+}
+
+func TestDefaultStatusSeverity(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "internal", err: errors.Internal("db unreachable"), want: codes.Error},
+		{name: "not found", err: errors.NotFound("user not found"), want: codes.Unset},
+		{
+			name: "internal with warning severity",
+			err:  errors.Build().Severity(errors.SeverityWarning).Wrap(errors.Internal("cache miss"), "get user"),
+			want: codes.Unset,
+		},
+		{
+			name: "not found with error severity",
+			err:  errors.Build().Type(errors.TypeNotFound).Severity(errors.SeverityError).New("config not found"),
+			want: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := DefaultStatus(tt.err); got != tt.want {
+				t.Errorf("DefaultStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributesSensitive(t *testing.T) {
+	err := errors.Build().
+		Attr("user_id", 10).
+		Attr("email", errors.Sensitive("john@example.com")).
+		New("user not found")
+
+	errors.SetRedactionPolicy(errors.RedactDrop)
+	t.Cleanup(func() { errors.SetRedactionPolicy(errors.RedactMask) })
+
+	for _, attr := range Attributes(err, false) {
+		if attr.Key == "email" {
+			t.Errorf("sensitive attribute should be dropped: %v", attr)
+		}
+	}
+}
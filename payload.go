@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultPayloadKey is the default key used by the serializers for the payload of an error
+const DefaultPayloadKey = "payload"
+
+var payloadKey atomic.Pointer[string]
+
+// SetPayloadKey sets the key used by the serializers (JSON, slog) for the payload of an error.
+// The payload is always nested under this key, so it cannot overwrite the other fields. An empty
+// key, or a key of the other fields (e.g. "message", "type"), resets it to DefaultPayloadKey
+func SetPayloadKey(key string) {
+	if _, reserved := serializedKeys()[key]; key == "" || reserved {
+		payloadKey.Store(nil)
+		return
+	}
+	payloadKey.Store(&key)
+}
+
+// serializedKeys returns the keys of the fields of the serializers
+var serializedKeys = sync.OnceValue(func() map[string]struct{} {
+	st := reflect.TypeOf(serialized{})
+	keys := make(map[string]struct{}, st.NumField())
+	for idx := range st.NumField() {
+		name, _, _ := strings.Cut(st.Field(idx).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = struct{}{}
+		}
+	}
+	return keys
+})
+
+func currentPayloadKey() string {
+	if key := payloadKey.Load(); key != nil {
+		return *key
+	}
+	return DefaultPayloadKey
+}
+
+// errorBase lets PayloadError embed *Error, since a field named Error would shadow the
+// Error() method
+type errorBase = Error
+
+// PayloadError is an *Error carrying domain data of type T, e.g. the ID of the conflicting
+// resource of a TypeDuplicate error. All the methods of *Error are available on it
+type PayloadError[T any] struct {
+	*errorBase
+}
+
+func (pe *PayloadError[T]) base() *Error {
+	if pe == nil {
+		return nil
+	}
+	return pe.errorBase
+}
+
+// Payload returns the payload of the error
+func (pe *PayloadError[T]) Payload() T {
+	payload, _ := pe.payload.(T)
+	return payload
+}
+
+// As lets errors.As (as well as AsType & AllAs) match the error with a target of type **Error, same
+// as the other functions of this package which treat it as an *Error
+func (pe *PayloadError[T]) As(target any) bool {
+	if t, ok := target.(**Error); ok && pe != nil {
+		*t = pe.errorBase
+		return true
+	}
+	return false
+}
+
+// AsError returns the underlying *Error
+func (pe *PayloadError[T]) AsError() *Error {
+	return pe.errorBase
+}
+
+// NewWithPayload returns an error instance with custom error type, carrying the payload
+func NewWithPayload[T any](payload T, msg string, etype errType) *PayloadError[T] {
+	err := Build().Type(etype).withPayload(payload).newerr(nil, msg, 3)
+	return &PayloadError[T]{errorBase: err}
+}
+
+// WrapWithPayload is same as Wrap, and the returned error carries the payload
+func WrapWithPayload[T any](original error, payload T, msg ...string) *PayloadError[T] {
+	message := strings.Join(msg, ". ")
+	err := Build().withPayload(payload).newerr(original, message, 3)
+	return &PayloadError[T]{errorBase: err}
+}
+
+// AsPayload returns the first payload of type T found in the error chain, including joined errors
+func AsPayload[T any](err error) (T, bool) {
+	var (
+		payload T
+		found   bool
+	)
+	walk(err, func(e *Error) bool {
+		payload, found = e.payload.(T)
+		return !found
+	})
+	return payload, found
+}
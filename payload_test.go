@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type conflict struct {
+	ResourceID string `json:"resource_id"`
+}
+
+func TestPayloadError(t *testing.T) {
+	err := NewWithPayload(conflict{ResourceID: "user-1"}, "user already exists", TypeDuplicate)
+	if got := err.Payload(); got.ResourceID != "user-1" {
+		t.Errorf("Payload() = %v, want user-1", got)
+	}
+	if got := err.Message(); got != "user already exists" {
+		t.Errorf("Message() = %q", got)
+	}
+	if !strings.Contains(err.Error(), "payload_test.go") {
+		t.Errorf("Error() = %q, expected file & line of the call site", err.Error())
+	}
+
+	var plain error = err
+	if got := Type(plain); got != TypeDuplicate {
+		t.Errorf("Type() = %v, want %v", got, TypeDuplicate)
+	}
+	if got, _ := HTTPStatusCode(plain); got != http.StatusConflict {
+		t.Errorf("HTTPStatusCode() = %d, want %d", got, http.StatusConflict)
+	}
+
+	wrapped := Wrap(err, "signup")
+	if got := wrapped.Type(); got != TypeDuplicate {
+		t.Errorf("Wrap().Type() = %v, want %v", got, TypeDuplicate)
+	}
+	if got := wrapped.Message(); got != "signup: user already exists" {
+		t.Errorf("Wrap().Message() = %q", got)
+	}
+	if !errors.Is(wrapped, err) {
+		t.Error("errors.Is() = false, want true")
+	}
+	if got := err.AsError(); !errors.Is(wrapped, got) {
+		t.Error("errors.Is() with the embedded *Error = false, want true")
+	}
+}
+
+func TestAsPayload(t *testing.T) {
+	quota := WrapWithPayload(errors.New("rate limited"), 42, "too many attempts")
+	tests := []struct {
+		name  string
+		err   error
+		want  int
+		found bool
+	}{
+		{name: "nil", err: nil},
+		{name: "no payload", err: New("hello")},
+		{name: "direct", err: quota, want: 42, found: true},
+		{name: "wrapped", err: Wrap(quota, "login"), want: 42, found: true},
+		{name: "std wrapped", err: fmt.Errorf("login: %w", quota), want: 42, found: true},
+		{name: "joined", err: Join(New("first"), quota), want: 42, found: true},
+		{
+			name:  "outermost wins",
+			err:   WrapWithPayload(quota, 7, "outer"),
+			want:  7,
+			found: true,
+		},
+		{
+			name: "other payload type",
+			err:  NewWithPayload("quota", "too many attempts", TypeMaximumAttempts),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := AsPayload[int](tt.err)
+			if got != tt.want || found != tt.found {
+				t.Errorf("AsPayload() = (%v, %v), want (%v, %v)", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestPayloadSerialization(t *testing.T) {
+	t.Cleanup(func() { SetPayloadKey("") })
+
+	err := Wrap(NewWithPayload(conflict{ResourceID: "user-1"}, "user already exists", TypeDuplicate), "signup")
+	raw, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+	if !strings.Contains(string(raw), `"payload":{"resource_id":"user-1"}`) {
+		t.Errorf("payload not serialized: %s", raw)
+	}
+
+	SetPayloadKey("details")
+	raw, jerr = json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+	if !strings.Contains(string(raw), `"details":{"resource_id":"user-1"}`) || strings.Contains(string(raw), `"payload"`) {
+		t.Errorf("payload not serialized with the configured key: %s", raw)
+	}
+
+	buff := bytes.NewBuffer(nil)
+	logger := slog.New(slog.NewTextHandler(buff, nil))
+	logger.Error("failed", slog.Any("err", err))
+	if got := buff.String(); !strings.Contains(got, "err.cause.details={ResourceID:user-1}") {
+		t.Errorf("payload not logged: %s", got)
+	}
+}
+
+func TestPayloadKeyReserved(t *testing.T) {
+	t.Cleanup(func() { SetPayloadKey("") })
+
+	err := NewWithPayload(map[string]string{"message": "overwritten"}, "user already exists", TypeDuplicate)
+	for _, key := range []string{"message", "type", "code", "attributes"} {
+		SetPayloadKey(key)
+		raw, jerr := json.Marshal(err)
+		if jerr != nil {
+			t.Fatalf("json.Marshal() error = %v", jerr)
+		}
+		got := map[string]any{}
+		_ = json.Unmarshal(raw, &got)
+		if got["message"] != "user already exists" || got["type"] != "Duplicate" {
+			t.Errorf("payload key %q overwrote the fields: %s", key, raw)
+		}
+		if _, ok := got[DefaultPayloadKey]; !ok {
+			t.Errorf("payload should be nested under %q: %s", DefaultPayloadKey, raw)
+		}
+	}
+}
+
+func TestPayloadAsError(t *testing.T) {
+	pe := NewWithPayload(conflict{ResourceID: "user-1"}, "user already exists", TypeDuplicate)
+	err := Join(Wrap(pe, "signup"), errors.New("std"))
+
+	if got, ok := AsType[*Error](pe); !ok || got != pe.AsError() {
+		t.Errorf("AsType() = %v, %v, want %v", got, ok, pe.AsError())
+	}
+
+	list := AllAs[*Error](err)
+	if len(list) != 2 || list[1] != pe.AsError() {
+		t.Errorf("AllAs() = %v, should include the *PayloadError", list)
+	}
+
+	var target *Error
+	if !errors.As(pe, &target) || target != pe.AsError() {
+		t.Errorf("errors.As() = %v", target)
+	}
+}
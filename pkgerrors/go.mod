@@ -0,0 +1,13 @@
+module github.com/naughtygopher/errors/pkgerrors
+
+go 1.23.0
+
+require (
+	github.com/naughtygopher/errors v1.3.2-0.20261019074338-eec0d1f30ec7
+	github.com/pkg/errors v0.9.1
+)
+
+require (
+	golang.org/x/sys v0.33.0 // indirect
+	google.golang.org/grpc v1.73.0 // indirect
+)
@@ -0,0 +1,84 @@
+// Package pkgerrors adapts errors of github.com/naughtygopher/errors to github.com/pkg/errors. It is
+// a separate module, so that the core module does not depend on github.com/pkg/errors.
+// Stacktraces of errors created using github.com/pkg/errors are supported by the core module
+// without this package, refer errors.ForeignProgramCounters
+package pkgerrors
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/naughtygopher/errors"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Adapt returns an error compatible with github.com/pkg/errors, i.e. it implements
+// `Cause() error`, `StackTrace() errors.StackTrace` and the '%+v' formatting convention. The
+// stacktrace is of the full chain, as returned by errors.ProgramCounters. The returned error
+// unwraps to err.
+func Adapt(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &pkgError{err: err}
+}
+
+type pkgError struct {
+	err error
+}
+
+func (pe *pkgError) Error() string {
+	return pe.err.Error()
+}
+
+// Unwrap returns the adapted error
+func (pe *pkgError) Unwrap() error {
+	return pe.err
+}
+
+// Cause implements the causer interface of github.com/pkg/errors
+func (pe *pkgError) Cause() error {
+	return errors.Cause(pe.err)
+}
+
+// StackTrace implements the stackTracer interface of github.com/pkg/errors
+func (pe *pkgError) StackTrace() pkgerrors.StackTrace {
+	pcs := errors.ProgramCounters(pe.err)
+	st := make(pkgerrors.StackTrace, 0, len(pcs))
+	for _, pc := range pcs {
+		if pc == 0 {
+			continue
+		}
+		st = append(st, pkgerrors.Frame(pc))
+	}
+	return st
+}
+
+// Format implements fmt.Formatter as per the github.com/pkg/errors convention. i.e. '%+v' prints
+// the messages followed by the stacktrace
+func (pe *pkgError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, message(pe.err))
+			pe.StackTrace().Format(s, verb)
+			return
+		}
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(s, pe.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", pe.Error())
+	}
+}
+
+// message returns the messages of the chain without the file & line, if err is an *errors.Error
+func message(err error) string {
+	switch e := err.(type) {
+	case *errors.Error:
+		return e.ErrorWithoutFileLine()
+	case interface{ AsError() *errors.Error }:
+		return e.AsError().ErrorWithoutFileLine()
+	}
+	return err.Error()
+}
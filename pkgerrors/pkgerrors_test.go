@@ -0,0 +1,83 @@
+package pkgerrors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/naughtygopher/errors"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestAdapt(t *testing.T) {
+	root := stderrors.New("root cause")
+	err := Adapt(errors.Wrap(root, "wrapped"))
+
+	if pkgerrors.Cause(err) != root {
+		t.Errorf("pkg/errors Cause() = %v, want %v", pkgerrors.Cause(err), root)
+	}
+	if errors.Cause(err) != root {
+		t.Errorf("Cause() = %v, want %v", errors.Cause(err), root)
+	}
+	if !stderrors.Is(err, root) {
+		t.Error("adapter should unwrap to the original error")
+	}
+
+	st := err.(interface{ StackTrace() pkgerrors.StackTrace }).StackTrace()
+	if len(st) == 0 || fmt.Sprintf("%n", st[0]) != "TestAdapt" {
+		t.Errorf("StackTrace() = %v", st)
+	}
+
+	got := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(got, "wrapped: root cause\ngithub.com/naughtygopher/errors/pkgerrors.TestAdapt\n\t") {
+		t.Errorf("%%+v = %q", got)
+	}
+	if got := fmt.Sprintf("%v", err); got != err.Error() {
+		t.Errorf("%%v = %q", got)
+	}
+
+	// chain with pkg/errors wrapping *Error
+	mixed := pkgerrors.Wrap(errors.NotFound("user not found"), "pkg wrap")
+	if !strings.Contains(fmt.Sprintf("%+v", mixed), "user not found") || errors.Type(errors.Cause(mixed)) != errors.TypeNotFound {
+		t.Errorf("unexpected mixed chain %+v", mixed)
+	}
+	if Adapt(nil) != nil {
+		t.Error("Adapt(nil) should be nil")
+	}
+}
+
+func pkgErrorsSite() error {
+	return pkgerrors.New("pkg error")
+}
+
+func TestForeignStacktrace(t *testing.T) {
+	err := errors.Wrap(pkgErrorsSite(), "wrapped pkg error")
+
+	if pcs := errors.ForeignProgramCounters(errors.Unwrap(err)); len(pcs) == 0 {
+		t.Fatal("ForeignProgramCounters() should return the pkg/errors stacktrace")
+	}
+
+	got := errors.Stacktrace(err)
+	if !strings.Contains(got, "pkgerrors.TestForeignStacktrace(): wrapped pkg error") ||
+		!strings.Contains(got, "pkgerrors.pkgErrorsSite(): pkg error") {
+		t.Errorf("Stacktrace() = %s", got)
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	root := stderrors.New("root cause")
+	err := errors.Wrap(errors.NotFoundErr(root, "user not found"), "get user")
+	if got := pkgerrors.Cause(err); got != root {
+		t.Errorf("pkg/errors Cause() = %v, want %v", got, root)
+	}
+
+	leaf := errors.NotFound("user not found")
+	got := pkgerrors.Cause(errors.Wrap(leaf, "get user"))
+	if got == nil || got.Error() != leaf.Error() || !stderrors.Is(got, leaf) {
+		t.Errorf("pkg/errors Cause() = %v, want %v", got, leaf)
+	}
+	if !errors.Is(got, errors.ErrNotFound) {
+		t.Errorf("pkg/errors Cause() = %v, expected to match %v", got, errors.ErrNotFound)
+	}
+}
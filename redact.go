@@ -0,0 +1,206 @@
+package errors
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// RedactionPolicy defines how sensitive values are rendered
+type RedactionPolicy int32
+
+const (
+	// RedactMask replaces all but the last 4 characters with '*'. Values shorter than 8 characters
+	// are masked entirely
+	RedactMask RedactionPolicy = iota
+	// RedactHash replaces the value with a truncated HMAC-SHA256 of it, so that values can still be
+	// correlated without being guessable. Refer SetRedactionKey
+	RedactHash
+	// RedactDrop replaces the value with "[REDACTED]", and sensitive attributes are omitted by the serializers
+	RedactDrop
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	redactionPolicy = atomic.Int32{}
+	redactionKey    = atomic.Pointer[[]byte]{}
+)
+
+func init() {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	redactionKey.Store(&key)
+}
+
+// SetRedactionPolicy sets the policy used to render sensitive values, RedactMask by default
+func SetRedactionPolicy(policy RedactionPolicy) {
+	redactionPolicy.Store(int32(policy))
+}
+
+// SetRedactionKey sets the key used to hash sensitive values with RedactHash. A random key is
+// generated at startup by default, so hashes can be correlated only within the same process. A
+// fixed key (e.g. from a secret store) should be set to correlate them across processes
+func SetRedactionKey(key []byte) {
+	key = append([]byte(nil), key...)
+	redactionKey.Store(&key)
+}
+
+type sensitive struct {
+	value any
+}
+
+// Sensitive marks a value as sensitive (e.g. email, token, card number). When used as an argument
+// of the formatted functions (Newf, Wrapf etc.) or as an attribute value, it is rendered as per the
+// RedactionPolicy in Error(), Message(), fmt directives and all the serializers.
+// The raw values are available only using the Unredacted* functions.
+func Sensitive(value any) any {
+	return sensitive{value: value}
+}
+
+// SensitiveAttribute returns an Attr with the value marked as Sensitive
+func SensitiveAttribute(key string, value any) Attr {
+	return Attr{Key: key, Value: Sensitive(value)}
+}
+
+func (s sensitive) String() string {
+	raw := fmt.Sprint(s.value)
+	switch RedactionPolicy(redactionPolicy.Load()) {
+	case RedactHash:
+		mac := hmac.New(sha256.New, *redactionKey.Load())
+		_, _ = mac.Write([]byte(raw))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:8])
+	case RedactDrop:
+		return redacted
+	}
+
+	runes := []rune(raw)
+	if len(runes) < 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
+
+// Format implements fmt.Formatter, all verbs print the redacted value
+func (s sensitive) Format(f fmt.State, verb rune) {
+	_, _ = f.Write([]byte(s.String()))
+}
+
+// MarshalJSON implements json.Marshaler
+func (s sensitive) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// LogValue implements slog.LogValuer
+func (s sensitive) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func isDropped(value any) bool {
+	_, ok := value.(sensitive)
+	return ok && RedactionPolicy(redactionPolicy.Load()) == RedactDrop
+}
+
+// IsSensitive returns true if the value of the attribute is marked as sensitive
+func (a Attr) IsSensitive() bool {
+	_, ok := a.Value.(sensitive)
+	return ok
+}
+
+// IsDropped returns true if the value of the attribute is marked as sensitive and the RedactionPolicy
+// is RedactDrop, i.e. the attribute should be omitted from the output
+func (a Attr) IsDropped() bool {
+	return isDropped(a.Value)
+}
+
+// Unredacted returns the raw value of the attribute. It should be used only for secure sinks
+func (a Attr) Unredacted() any {
+	if s, ok := a.Value.(sensitive); ok {
+		return s.value
+	}
+	return a.Value
+}
+
+// hasSensitive returns true if any of the arguments is Sensitive
+func hasSensitive(args []any) bool {
+	for _, arg := range args {
+		if _, ok := arg.(sensitive); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unredactedArgs returns the arguments with the raw values of the sensitive ones
+func unredactedArgs(args []any) []any {
+	raw := make([]any, len(args))
+	for idx, arg := range args {
+		if s, ok := arg.(sensitive); ok {
+			arg = s.value
+		}
+		raw[idx] = arg
+	}
+	return raw
+}
+
+// text returns the message of the error. Messages with sensitive arguments are formatted when
+// rendered, so that they are redacted as per the current RedactionPolicy
+func (e *Error) text() string {
+	if e.args == nil {
+		return e.message
+	}
+	return fmt.Sprintf(e.format, e.args...)
+}
+
+// UnredactedMessage is same as Message, but with the raw values of sensitive arguments. It should
+// be used only for secure sinks
+func (e *Error) UnredactedMessage() string {
+	messages := make([]string, 0, 5)
+	for err := e; err != nil; err, _ = asError(err.original) {
+		msg := err.message
+		if err.args != nil {
+			msg = fmt.Sprintf(err.format, unredactedArgs(err.args)...)
+		}
+		if msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+
+	if len(messages) > 0 {
+		return strings.Join(messages, ": ")
+	}
+
+	return e.Error()
+}
+
+// UnredactedMessage is same as the package level Message function, but with the raw values of
+// sensitive arguments. It should be used only for secure sinks
+func UnredactedMessage(err error) (string, bool) {
+	derr, _ := asError(err)
+	if derr != nil {
+		return derr.UnredactedMessage(), true
+	}
+
+	jerr, _ := err.(*joinError)
+	if jerr != nil {
+		list := make([]string, 0, len(jerr.errs))
+		isErr := true
+		for i := range jerr.errs {
+			msg, ok := UnredactedMessage(jerr.errs[i])
+			isErr = isErr && ok
+			if msg == "" {
+				continue
+			}
+			list = append(list, msg)
+		}
+		return strings.Join(list, "\n"), isErr
+	}
+
+	return "", false
+}
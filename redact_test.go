@@ -0,0 +1,146 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func setTestRedactionPolicy(t *testing.T, policy RedactionPolicy) {
+	t.Helper()
+	before := redactionPolicy.Load()
+	t.Cleanup(func() { redactionPolicy.Store(before) })
+	SetRedactionPolicy(policy)
+}
+
+func TestSensitiveMessage(t *testing.T) {
+	setTestRedactionPolicy(t, RedactMask)
+
+	err := Wrapf(
+		Validationf("invalid card %s", Sensitive("4111111111111111")),
+		"payment failed for %s", Sensitive("john@example.com"),
+	)
+
+	want := "payment failed for ************.com: invalid card ************1111"
+	if got := err.Message(); got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+	if got := fmt.Sprintf("%v", err); got != want {
+		t.Errorf("%%v = %q, want %q", got, want)
+	}
+	for _, got := range []string{err.Error(), fmt.Sprintf("%+v", err), fmt.Sprintf("%+s", err)} {
+		if strings.Contains(got, "john@example.com") || strings.Contains(got, "4111111111111111") {
+			t.Errorf("sensitive value leaked: %q", got)
+		}
+	}
+
+	want = "payment failed for john@example.com: invalid card 4111111111111111"
+	if got := err.UnredactedMessage(); got != want {
+		t.Errorf("UnredactedMessage() = %q, want %q", got, want)
+	}
+	if got, ok := UnredactedMessage(Join(err)); !ok || got != want {
+		t.Errorf("UnredactedMessage() = %q, %v, want %q", got, ok, want)
+	}
+}
+
+func setTestRedactionKey(t *testing.T, key []byte) {
+	t.Helper()
+	before := redactionKey.Load()
+	t.Cleanup(func() { redactionKey.Store(before) })
+	SetRedactionKey(key)
+}
+
+func TestRedactionPolicy(t *testing.T) {
+	setTestRedactionKey(t, []byte("test-key"))
+
+	tests := []struct {
+		name   string
+		policy RedactionPolicy
+		value  any
+		want   string
+	}{
+		{name: "mask short", policy: RedactMask, value: "secret", want: "******"},
+		{name: "mask long", policy: RedactMask, value: "supersecret", want: "*******cret"},
+		{name: "hash", policy: RedactHash, value: "secret", want: "hmac:9d5ff9421d787dcd"},
+		{name: "drop", policy: RedactDrop, value: 1234, want: "[REDACTED]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestRedactionPolicy(t, tt.policy)
+			if got := fmt.Sprintf("%d", Sensitive(tt.value)); got != tt.want {
+				t.Errorf("Sensitive() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSensitiveAttributes(t *testing.T) {
+	setTestRedactionPolicy(t, RedactMask)
+
+	err := NotFound("user not found")
+	err.attrs = []Attr{SensitiveAttribute("email", "john@example.com"), Attribute("user_id", 1)}
+
+	raw, _ := json.Marshal(err)
+	if !strings.Contains(string(raw), `"attributes":{"email":"************.com","user_id":1}`) {
+		t.Errorf("unexpected JSON %s", raw)
+	}
+
+	buff := bytes.NewBuffer(nil)
+	slog.New(slog.NewTextHandler(buff, nil)).Error("failed", slog.Any("err", err))
+	if strings.Contains(buff.String(), "john@example.com") || !strings.Contains(buff.String(), "err.attributes.email=************.com") {
+		t.Errorf("unexpected log %s", buff.String())
+	}
+
+	attr := err.Attributes()[0]
+	if !attr.IsSensitive() || attr.Unredacted() != "john@example.com" {
+		t.Errorf("Unredacted() = %v", attr.Unredacted())
+	}
+	if err.Attributes()[1].IsSensitive() || err.Attributes()[1].Unredacted() != 1 {
+		t.Error("non sensitive attribute")
+	}
+
+	SetRedactionPolicy(RedactDrop)
+	raw, _ = json.Marshal(err)
+	if !strings.Contains(string(raw), `"attributes":{"user_id":1}`) {
+		t.Errorf("unexpected JSON %s", raw)
+	}
+	if _, ok := NewSentryEvent(err).Tags["email"]; ok {
+		t.Error("dropped attribute should not be a sentry tag")
+	}
+}
+
+func TestRedactionKey(t *testing.T) {
+	setTestRedactionPolicy(t, RedactHash)
+	value := Sensitive("secret")
+
+	setTestRedactionKey(t, []byte("first"))
+	first := fmt.Sprint(value)
+	if first != fmt.Sprint(Sensitive("secret")) {
+		t.Error("hash should be stable for the same key")
+	}
+
+	SetRedactionKey([]byte("second"))
+	if fmt.Sprint(value) == first {
+		t.Error("hash should depend on the key")
+	}
+}
+
+func TestRedactionAtRender(t *testing.T) {
+	setTestRedactionPolicy(t, RedactMask)
+	err := Validationf("invalid card %s", Sensitive("4111111111111111"))
+	err.attrs = []Attr{SensitiveAttribute("email", "john@example.com")}
+
+	SetRedactionPolicy(RedactDrop)
+	if got, want := err.Message(), "invalid card [REDACTED]"; got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+	if !err.Attributes()[0].IsDropped() {
+		t.Error("IsDropped() = false, want true")
+	}
+	if got, want := err.UnredactedMessage(), "invalid card 4111111111111111"; got != want {
+		t.Errorf("UnredactedMessage() = %q, want %q", got, want)
+	}
+}
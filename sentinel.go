@@ -0,0 +1,54 @@
+package errors
+
+// sentinel is matched by errors.Is against any *Error of the same type or code, refer
+// TypeSentinel and CodeSentinel
+type sentinel struct {
+	eType  errType
+	code   string
+	byCode bool
+}
+
+func (s *sentinel) Error() string {
+	if s.byCode {
+		return "errors: code " + s.code
+	}
+	return "errors: " + s.eType.String()
+}
+
+func (s *sentinel) matches(e *Error) bool {
+	if s.byCode {
+		return s.code != "" && e.code == s.code
+	}
+	return e.eType == s.eType
+}
+
+// Sentinels of the built-in error types. errors.Is(err, ErrNotFound) is true if there's any *Error of
+// type TypeNotFound in the chain of err
+var (
+	ErrInternal                     = TypeSentinel(TypeInternal)
+	ErrValidation                   = TypeSentinel(TypeValidation)
+	ErrInputBody                    = TypeSentinel(TypeInputBody)
+	ErrDuplicate                    = TypeSentinel(TypeDuplicate)
+	ErrUnauthenticated              = TypeSentinel(TypeUnauthenticated)
+	ErrUnauthorized                 = TypeSentinel(TypeUnauthorized)
+	ErrEmpty                        = TypeSentinel(TypeEmpty)
+	ErrNotFound                     = TypeSentinel(TypeNotFound)
+	ErrMaximumAttempts              = TypeSentinel(TypeMaximumAttempts)
+	ErrSubscriptionExpired          = TypeSentinel(TypeSubscriptionExpired)
+	ErrDownstreamDependencyTimedout = TypeSentinel(TypeDownstreamDependencyTimedout)
+	ErrNotImplemented               = TypeSentinel(TypeNotImplemented)
+	ErrContextTimedout              = TypeSentinel(TypeContextTimedout)
+	ErrContextCancelled             = TypeSentinel(TypeContextCancelled)
+)
+
+// TypeSentinel returns a sentinel error which, when used as the target of errors.Is, matches any
+// *Error of the given type. It is useful for types registered using RegisterType
+func TypeSentinel(et errType) error {
+	return &sentinel{eType: et}
+}
+
+// CodeSentinel returns a sentinel error which, when used as the target of errors.Is, matches any
+// *Error with the given code. An empty code matches nothing, since it's not a code
+func CodeSentinel(code string) error {
+	return &sentinel{code: code, byCode: true}
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSentinels(t *testing.T) {
+	notFound := NotFound("user not found")
+	quota := NewWithCode("quota_exceeded", "too many requests", TypeMaximumAttempts)
+	custom, _ := RegisterType("SentinelCustom")
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{name: "type", err: notFound, target: ErrNotFound, want: true},
+		{name: "different type", err: notFound, target: ErrValidation, want: false},
+		{name: "wrapped", err: Wrap(notFound, "get user"), target: ErrNotFound, want: true},
+		{name: "std wrapped", err: fmt.Errorf("get user: %w", notFound), target: ErrNotFound, want: true},
+		{name: "outer type", err: InternalErr(notFound, "get user"), target: ErrInternal, want: true},
+		{name: "joined", err: Join(errors.New("std"), notFound), target: ErrNotFound, want: true},
+		{name: "foreign", err: errors.New("not found"), target: ErrNotFound, want: false},
+		{name: "nil", err: nil, target: ErrNotFound, want: false},
+		{name: "code", err: Wrap(quota), target: CodeSentinel("quota_exceeded"), want: true},
+		{name: "different code", err: quota, target: CodeSentinel("rate_limited"), want: false},
+		{name: "empty code", err: New("no code"), target: CodeSentinel(""), want: false},
+		{name: "registered type", err: NewWithType("custom", custom), target: TypeSentinel(custom), want: true},
+		{name: "identity", err: Wrap(notFound), target: notFound, want: true},
+		{name: "other instance", err: notFound, target: NotFound("user not found"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := ErrNotFound.Error(); got != "errors: NotFound" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := CodeSentinel("quota_exceeded").Error(); got != "errors: code quota_exceeded" {
+		t.Errorf("Error() = %q", got)
+	}
+}
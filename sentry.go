@@ -0,0 +1,304 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SentryEvent is an event as per the Sentry event payload schema
+type SentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Message     string            `json:"message,omitempty"`
+	Exception   SentryExceptions  `json:"exception"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+}
+
+// SentryExceptions is the list of exceptions of an event, ordered from the innermost (root cause)
+// to the outermost error. The tree of joined errors is described by the mechanism of each exception
+type SentryExceptions struct {
+	Values []SentryException `json:"values"`
+}
+
+// SentryException is a single error in the tree
+type SentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Module     string            `json:"module,omitempty"`
+	Stacktrace *SentryStacktrace `json:"stacktrace,omitempty"`
+	Mechanism  *SentryMechanism  `json:"mechanism,omitempty"`
+}
+
+// SentryMechanism describes the position of an exception in the error tree. The outermost error has
+// ExceptionID 0, and every other exception has the ExceptionID of the error wrapping (or joining) it
+// as ParentID
+type SentryMechanism struct {
+	Type             string `json:"type"`
+	Source           string `json:"source,omitempty"`
+	ExceptionID      int    `json:"exception_id"`
+	ParentID         *int   `json:"parent_id,omitempty"`
+	IsExceptionGroup bool   `json:"is_exception_group,omitempty"`
+}
+
+// SentryStacktrace is the stacktrace of an exception, frames are ordered from the oldest (outermost
+// caller) to the newest
+type SentryStacktrace struct {
+	Frames []SentryFrame `json:"frames"`
+}
+
+// SentryFrame is a single stack frame
+type SentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+// NewSentryEvent converts the error tree to a Sentry event. There is one exception per error in the
+// chain (including joined errors); only the *Error ones have a stacktrace. Frames are marked 'in app'
+// if their package path has any of the given prefixes. If no prefixes are provided, Frame.InApp is used.
+// Type & code of the error, as well as its attributes are added as tags.
+func NewSentryEvent(err error, inAppPrefixes ...string) *SentryEvent {
+	// the time when the error was last wrapped, if recorded
+	at := LastTime(err)
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	event := &SentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   at.UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       sentryLevel(err),
+		Exception:   SentryExceptions{Values: sentryExceptions(err, inAppPrefixes)},
+		Tags:        map[string]string{},
+		Fingerprint: []string{Fingerprint(err)},
+	}
+	event.Message, _ = Message(err)
+
+	if et := Type(err); et.Int() != -1 {
+		event.Tags["error.type"] = et.String()
+	}
+	if code := Code(err); code != "" {
+		event.Tags["error.code"] = code
+	}
+	for _, attr := range Attributes(err) {
+		if isDropped(attr.Value) {
+			continue
+		}
+		event.Tags[attr.Key] = fmt.Sprint(attr.Value)
+	}
+
+	return event
+}
+
+func sentryExceptions(err error, inAppPrefixes []string) []SentryException {
+	list := make([]SentryException, 0, 8)
+	var collect func(err error, parent *int, source string)
+	collect = func(err error, parent *int, source string) {
+		for err != nil {
+			mechanism := &SentryMechanism{
+				Type:        "generic",
+				Source:      source,
+				ExceptionID: len(list),
+				ParentID:    parent,
+			}
+			switch e := unembed(err).(type) {
+			case *Error:
+				list = append(list, SentryException{
+					Type:       e.eType.String(),
+					Value:      e.text(),
+					Module:     funcPackage(e.fileFunction()),
+					Stacktrace: sentryStacktrace(e.Frames(), inAppPrefixes),
+					Mechanism:  mechanism,
+				})
+			case interface{ Unwrap() []error }:
+				mechanism.IsExceptionGroup = true
+				list = append(list, SentryException{
+					Type:      fmt.Sprintf("%T", err),
+					Mechanism: mechanism,
+				})
+				for idx, inner := range e.Unwrap() {
+					collect(inner, &mechanism.ExceptionID, "errors["+strconv.Itoa(idx)+"]")
+				}
+				return
+			default:
+				list = append(list, SentryException{
+					Type:      fmt.Sprintf("%T", err),
+					Value:     err.Error(),
+					Mechanism: mechanism,
+				})
+			}
+			parent, source = &mechanism.ExceptionID, ""
+			err = Unwrap(err)
+		}
+	}
+	collect(err, nil, "")
+
+	// Sentry expects the root cause to be the first exception, the tree is rebuilt using the
+	// exception & parent IDs
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+
+	return list
+}
+
+func sentryStacktrace(frames []Frame, inAppPrefixes []string) *SentryStacktrace {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	// Sentry expects the oldest frame first
+	list := make([]SentryFrame, 0, len(frames))
+	for idx := len(frames) - 1; idx >= 0; idx-- {
+		frame := frames[idx]
+		if len(inAppPrefixes) > 0 {
+			frame.InApp = hasPkgPrefix(frame.Package, inAppPrefixes)
+		}
+		list = append(list, SentryFrame{
+			Function: strings.TrimPrefix(frame.Function, frame.Package+"."),
+			Module:   frame.Package,
+			Filename: modulePath(frame.Function, frame.File),
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+			InApp:    frame.InApp,
+		})
+	}
+
+	return &SentryStacktrace{Frames: list}
+}
+
+func newEventID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// SentryTransport sends events to Sentry (or any compatible service) as envelopes
+type SentryTransport struct {
+	// DSN is the Sentry DSN, e.g. https://public@sentry.example.com/1
+	DSN string
+	// Client is the HTTP client used to send the events, http.DefaultClient if nil
+	Client *http.Client
+}
+
+// Send posts the event as an envelope to the envelope endpoint of the DSN
+func (st *SentryTransport) Send(ctx context.Context, event *SentryEvent) error {
+	dsn, err := url.Parse(st.DSN)
+	if err != nil {
+		return Wrap(err, "invalid sentry DSN")
+	}
+
+	// the project ID is the last segment of the path, the preceding segments (if any) are the
+	// path prefix of the Sentry instance, e.g. https://public@example.com/sentry/1
+	prefix, projectID := path.Split(strings.TrimSuffix(dsn.Path, "/"))
+	if dsn.User == nil || projectID == "" {
+		return Validation("invalid sentry DSN, public key and project ID are required")
+	}
+	publicKey := dsn.User.Username()
+
+	endpoint := url.URL{
+		Scheme: dsn.Scheme,
+		Host:   dsn.Host,
+		Path:   path.Join("/", prefix, "api", projectID, "envelope") + "/",
+	}
+
+	envelope, err := SentryEnvelope(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(envelope))
+	if err != nil {
+		return Wrap(err, "failed to create sentry request")
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set(
+		"X-Sentry-Auth",
+		"Sentry sentry_version=7, sentry_client=naughtygopher-errors, sentry_key="+publicKey,
+	)
+
+	client := st.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if isTimeout(err) {
+			return DownstreamDependencyTimedoutErr(err, "failed to send event to sentry")
+		}
+		return Wrap(err, "failed to send event to sentry")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return Internalf("sentry responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// isTimeout returns true if the error is due to a timeout, i.e. a deadline exceeded or a network timeout
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr) && nerr.Timeout()
+}
+
+// SentryEnvelope returns the event in the Sentry envelope format
+func SentryEnvelope(event *SentryEvent) ([]byte, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, Wrap(err, "failed to marshal sentry event")
+	}
+
+	header, _ := json.Marshal(map[string]string{
+		"event_id": event.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	itemHeader, _ := json.Marshal(map[string]any{
+		"type":   "event",
+		"length": len(payload),
+	})
+
+	buff := bytes.NewBuffer(make([]byte, 0, len(payload)+len(header)+len(itemHeader)+3))
+	buff.Write(header)
+	buff.WriteString("\n")
+	buff.Write(itemHeader)
+	buff.WriteString("\n")
+	buff.Write(payload)
+	buff.WriteString("\n")
+
+	return buff.Bytes(), nil
+}
+
+// sentryLevel returns the level of the event based on the severity of the error, "error" if not set
+func sentryLevel(err error) string {
+	if severity := SeverityOf(err); severity != SeverityUnset {
+		return severity.String()
+	}
+	return "error"
+}
@@ -0,0 +1,177 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSentryEvent(t *testing.T) {
+	inner := WrapWithCode(errors.New("connection refused"), "DB001", "query failed")
+	err := Wrap(Join(inner, NotFound("user not found")), "get user")
+
+	event := NewSentryEvent(err, "github.com/naughtygopher/errors")
+	values := event.Exception.Values
+	if len(values) != 5 {
+		t.Fatalf("expected 5 exceptions, got %d: %+v", len(values), values)
+	}
+
+	// root cause first, the tree is described using the exception & parent IDs
+	type exception struct {
+		Type     string
+		Value    string
+		ID       int
+		ParentID int
+		Source   string
+	}
+	want := []exception{
+		{Type: "NotFound", Value: "user not found", ID: 4, ParentID: 1, Source: "errors[1]"},
+		{Type: "*errors.errorString", Value: "connection refused", ID: 3, ParentID: 2},
+		{Type: "Internal", Value: "query failed", ID: 2, ParentID: 1, Source: "errors[0]"},
+		{Type: "*errors.joinError", ID: 1, ParentID: 0},
+		{Type: "Internal", Value: "get user", ID: 0, ParentID: -1},
+	}
+	for idx, exc := range values {
+		got := exception{
+			Type:     exc.Type,
+			Value:    exc.Value,
+			ID:       exc.Mechanism.ExceptionID,
+			ParentID: -1,
+			Source:   exc.Mechanism.Source,
+		}
+		if exc.Mechanism.ParentID != nil {
+			got.ParentID = *exc.Mechanism.ParentID
+		}
+		if got != want[idx] {
+			t.Errorf("exception[%d] = %+v, want %+v", idx, got, want[idx])
+		}
+	}
+	if !values[3].Mechanism.IsExceptionGroup {
+		t.Error("joined errors should be an exception group")
+	}
+	if values[1].Stacktrace != nil {
+		t.Error("non *Error should not have a stacktrace")
+	}
+
+	outer := values[4]
+	if outer.Module != "github.com/naughtygopher/errors" {
+		t.Errorf("Module = %q", outer.Module)
+	}
+	frames := outer.Stacktrace.Frames
+	last := frames[len(frames)-1]
+	if last.Function != "TestNewSentryEvent" || !last.InApp || last.Lineno == 0 ||
+		last.Filename != "github.com/naughtygopher/errors/sentry_test.go" {
+		t.Errorf("unexpected frame %+v", last)
+	}
+	if frames[0].InApp {
+		t.Errorf("runtime frame should not be in app: %+v", frames[0])
+	}
+
+	if event.Tags["error.type"] != "Internal" || event.Tags["error.code"] != "DB001" {
+		t.Errorf("unexpected tags %v", event.Tags)
+	}
+	if len(event.EventID) != 32 || event.Fingerprint[0] != Fingerprint(err) {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
+
+func TestSentryTransport(t *testing.T) {
+	var (
+		gotPath string
+		gotAuth string
+		gotBody []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("X-Sentry-Auth")
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://publickey@", 1) + "/42"
+	transport := &SentryTransport{DSN: dsn, Client: server.Client()}
+	event := NewSentryEvent(Validation("invalid email"))
+	err := transport.Send(context.Background(), event)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if gotPath != "/api/42/envelope/" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if !strings.Contains(gotAuth, "sentry_key=publickey") {
+		t.Errorf("X-Sentry-Auth = %q", gotAuth)
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(gotBody), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 envelope lines, got %d: %s", len(lines), gotBody)
+	}
+	header := map[string]any{}
+	_ = json.Unmarshal(lines[0], &header)
+	if header["event_id"] != event.EventID {
+		t.Errorf("envelope header = %s", lines[0])
+	}
+	item := map[string]any{}
+	_ = json.Unmarshal(lines[1], &item)
+	if item["type"] != "event" || int(item["length"].(float64)) != len(lines[2]) {
+		t.Errorf("item header = %s", lines[1])
+	}
+	got := SentryEvent{}
+	_ = json.Unmarshal(lines[2], &got)
+	if got.EventID != event.EventID || got.Exception.Values[0].Type != "Validation" {
+		t.Errorf("unexpected event %s", lines[2])
+	}
+
+	transport.DSN = server.URL
+	if err := transport.Send(context.Background(), event); err == nil {
+		t.Error("Send() expected error for DSN without key & project")
+	}
+}
+
+func TestSentryTransportDSN(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://publickey@", 1) + "/sentry/42"
+	transport := &SentryTransport{DSN: dsn, Client: server.Client()}
+	if err := transport.Send(context.Background(), NewSentryEvent(Validation("invalid email"))); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if gotPath != "/sentry/api/42/envelope/" {
+		t.Errorf("path = %q", gotPath)
+	}
+}
+
+func TestSentryTransportErrors(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	dsn := strings.Replace(server.URL, "://", "://publickey@", 1) + "/42"
+	event := NewSentryEvent(Validation("invalid email"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	transport := &SentryTransport{DSN: dsn, Client: server.Client()}
+	if err := transport.Send(ctx, event); Type(err) != TypeDownstreamDependencyTimedout {
+		t.Errorf("Type() = %v, want %v", Type(err), TypeDownstreamDependencyTimedout)
+	}
+
+	close(release)
+	server.Close()
+	if err := transport.Send(context.Background(), event); Type(err) != TypeInternal {
+		t.Errorf("Type() = %v, want %v", Type(err), TypeInternal)
+	}
+}
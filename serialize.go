@@ -0,0 +1,228 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// serialized is the structure used by all the serializers (JSON, slog etc.) of *Error
+type serialized struct {
+	Type        errType  `json:"type"`
+	Code        string   `json:"code,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+	Message     string   `json:"message,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Retryable   bool     `json:"retryable,omitempty"`
+	RetryAfter  string   `json:"retry_after,omitempty"`
+	MessageKey  string   `json:"message_key,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Time        string   `json:"time,omitempty"`
+	Attributes  attrList `json:"attributes,omitempty"`
+	Cause       any      `json:"cause,omitempty"`
+	// Payload is serialized under the key set using SetPayloadKey
+	Payload any `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, adding the payload under the configured key
+func (s serialized) MarshalJSON() ([]byte, error) {
+	type plain serialized
+	out, err := json.Marshal(plain(s))
+	if err != nil || s.Payload == nil {
+		return out, err
+	}
+
+	key, err := json.Marshal(currentPayloadKey())
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(s.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	buff := bytes.NewBuffer(make([]byte, 0, len(out)+len(key)+len(payload)+2))
+	buff.Write(out[:len(out)-1])
+	buff.WriteString(",")
+	buff.Write(key)
+	buff.WriteString(":")
+	buff.Write(payload)
+	buff.WriteString("}")
+	return buff.Bytes(), nil
+}
+
+// attrList is serialized as a JSON object, preserving the order of the attributes
+type attrList []Attr
+
+func (list attrList) MarshalJSON() ([]byte, error) {
+	buff := bytes.NewBuffer(make([]byte, 0, 64))
+	buff.WriteString("{")
+	written := 0
+	for _, attr := range list {
+		if isDropped(attr.Value) {
+			continue
+		}
+		if written > 0 {
+			buff.WriteString(",")
+		}
+		written++
+		key, err := json.Marshal(attr.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(attr.Value)
+		if err != nil {
+			return nil, err
+		}
+		buff.Write(key)
+		buff.WriteString(":")
+		buff.Write(value)
+	}
+	buff.WriteString("}")
+	return buff.Bytes(), nil
+}
+
+// serializedForeign is used to serialize errors which are not of type *Error. The message is
+// included only in the detailed form
+type serializedForeign struct {
+	Type    string `json:"type"`
+	Message string `json:"message,omitempty"`
+}
+
+func serializeCause(err error, detailed bool) any {
+	switch e := unembed(err).(type) {
+	case nil:
+		return nil
+	case *Error:
+		return e.serialize(detailed)
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		causes := make([]any, 0, len(errs))
+		for _, inner := range errs {
+			causes = append(causes, serializeCause(inner, detailed))
+		}
+		return causes
+	}
+
+	foreign := serializedForeign{Type: fmt.Sprintf("%T", err)}
+	if detailed {
+		foreign.Message = err.Error()
+	}
+	return foreign
+}
+
+// serialize returns the serializable form of the error. The location is module qualified, so that
+// the paths of the build machine are not exposed. If detailed is false, the messages of errors
+// which are not of type *Error are not included, since they are likely to have internal details
+func (e *Error) serialize(detailed bool) serialized {
+	s := serialized{
+		Type:       e.eType,
+		Code:       e.code,
+		Message:    e.text(),
+		Severity:   e.severity.String(),
+		Retryable:  e.retryable,
+		MessageKey: e.msgKey,
+		Location:   e.fileLineWith(TraceOptions{PathMode: PathModule}),
+		Time:       e.formattedTime(),
+		Cause:      serializeCause(e.original, detailed),
+		Payload:    e.payload,
+	}
+
+	if e.retryable && e.retryAfter > 0 {
+		s.RetryAfter = e.retryAfter.String()
+	}
+
+	if len(e.attrs) > 0 {
+		s.Attributes = attrList(e.attrs)
+	}
+
+	return s
+}
+
+// MarshalJSON implements json.Marshaler. Wrapped errors are nested under the key "cause", errors
+// which are not of type *Error are serialized only by their type (e.g. "*net.OpError")
+func (e *Error) MarshalJSON() ([]byte, error) {
+	s := e.serialize(false)
+	s.Fingerprint = Fingerprint(e)
+	return json.Marshal(s)
+}
+
+// LogValue implements slog.LogValuer, so that the error is logged as a group of its fields when
+// used with log/slog. Since logs are meant for developers, the messages of wrapped errors which are
+// not of type *Error are included
+func (e *Error) LogValue() slog.Value {
+	s := e.serialize(true)
+	s.Fingerprint = Fingerprint(e)
+	return serializedLogValue(s)
+}
+
+func serializedLogValue(s serialized) slog.Value {
+	attrs := make([]slog.Attr, 0, 5)
+	attrs = append(attrs, slog.String("type", s.Type.String()))
+	if s.Code != "" {
+		attrs = append(attrs, slog.String("code", s.Code))
+	}
+	if s.Fingerprint != "" {
+		attrs = append(attrs, slog.String("fingerprint", s.Fingerprint))
+	}
+	if s.Message != "" {
+		attrs = append(attrs, slog.String("message", s.Message))
+	}
+	if s.Severity != "" {
+		attrs = append(attrs, slog.String("severity", s.Severity))
+	}
+	if s.Retryable {
+		attrs = append(attrs, slog.Bool("retryable", true))
+	}
+	if s.RetryAfter != "" {
+		attrs = append(attrs, slog.String("retry_after", s.RetryAfter))
+	}
+	if s.MessageKey != "" {
+		attrs = append(attrs, slog.String("message_key", s.MessageKey))
+	}
+	if s.Location != "" {
+		attrs = append(attrs, slog.String("location", s.Location))
+	}
+	if s.Time != "" {
+		attrs = append(attrs, slog.String("time", s.Time))
+	}
+	if len(s.Attributes) > 0 {
+		list := make([]any, 0, len(s.Attributes))
+		for _, attr := range s.Attributes {
+			if isDropped(attr.Value) {
+				continue
+			}
+			list = append(list, slog.Any(attr.Key, attr.Value))
+		}
+		attrs = append(attrs, slog.Group("attributes", list...))
+	}
+	if s.Payload != nil {
+		attrs = append(attrs, slog.Any(currentPayloadKey(), s.Payload))
+	}
+	if s.Cause != nil {
+		attrs = append(attrs, slog.Attr{Key: "cause", Value: causeLogValue(s.Cause)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func causeLogValue(cause any) slog.Value {
+	switch c := cause.(type) {
+	case serialized:
+		return serializedLogValue(c)
+	case serializedForeign:
+		if c.Message == "" {
+			return slog.StringValue(c.Type)
+		}
+		return slog.StringValue(c.Message)
+	case []any:
+		attrs := make([]slog.Attr, 0, len(c))
+		for idx, inner := range c {
+			attrs = append(attrs, slog.Attr{Key: strconv.Itoa(idx), Value: causeLogValue(inner)})
+		}
+		return slog.GroupValue(attrs...)
+	}
+	return slog.AnyValue(cause)
+}
@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	inner := NotFound("user not found")
+	inner.attrs = []Attr{Attribute("user_id", 10), Attribute("tenant", "acme")}
+	err := Wrap(Join(inner, errors.New("pq: password auth failed for user admin")), "get user")
+
+	raw, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatalf("json.Marshal() error = %v", jerr)
+	}
+
+	got := map[string]any{}
+	if jerr = json.Unmarshal(raw, &got); jerr != nil {
+		t.Fatalf("json.Unmarshal() error = %v", jerr)
+	}
+	if got["type"] != "Internal" || got["message"] != "get user" {
+		t.Errorf("unexpected JSON %s", raw)
+	}
+	if got["location"] != "github.com/naughtygopher/errors/serialize_test.go:15" {
+		t.Errorf("unexpected location %s, expected module qualified path", got["location"])
+	}
+
+	causes, _ := got["cause"].([]any)
+	if len(causes) != 2 {
+		t.Fatalf("unexpected cause %s", raw)
+	}
+	first := causes[0].(map[string]any)
+	if first["type"] != "NotFound" || first["message"] != "user not found" {
+		t.Errorf("unexpected cause %v", first)
+	}
+	if !strings.Contains(string(raw), `"attributes":{"user_id":10,"tenant":"acme"}`) {
+		t.Errorf("attributes not serialized in order: %s", raw)
+	}
+	second := causes[1].(map[string]any)
+	if second["type"] != "*errors.errorString" || strings.Contains(string(raw), "password") {
+		t.Errorf("unexpected cause %v, foreign errors should be serialized by type only", second)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	err := Wrap(Validation("invalid email"), "signup")
+	err.attrs = []Attr{Attribute("request_id", "req-1")}
+
+	buff := bytes.NewBuffer(nil)
+	logger := slog.New(slog.NewTextHandler(buff, nil))
+	logger.Error("failed", slog.Any("err", err))
+
+	got := buff.String()
+	for _, want := range []string{
+		"err.type=Validation",
+		"err.message=signup",
+		"err.attributes.request_id=req-1",
+		"err.cause.type=Validation",
+		`err.cause.message="invalid email"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("log output %q does not contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sourceCacheSize is the maximum number of source files cached by StacktraceWithSource
+const sourceCacheSize = 64
+
+// sourceCache is an LRU cache of the lines of source files. The lines are nil if the file could
+// not be read
+type sourceCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	files map[string]*list.Element
+}
+
+type sourceEntry struct {
+	file  string
+	lines []string
+}
+
+func newSourceCache(size int) *sourceCache {
+	return &sourceCache{
+		size:  size,
+		order: list.New(),
+		files: make(map[string]*list.Element, size),
+	}
+}
+
+func (sc *sourceCache) Load(file string) ([]string, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	elem, ok := sc.files[file]
+	if !ok {
+		return nil, false
+	}
+	sc.order.MoveToFront(elem)
+	return elem.Value.(*sourceEntry).lines, true
+}
+
+func (sc *sourceCache) Store(file string, lines []string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if elem, ok := sc.files[file]; ok {
+		elem.Value.(*sourceEntry).lines = lines
+		sc.order.MoveToFront(elem)
+		return
+	}
+
+	sc.files[file] = sc.order.PushFront(&sourceEntry{file: file, lines: lines})
+	if sc.order.Len() > sc.size {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.files, oldest.Value.(*sourceEntry).file)
+	}
+}
+
+// sourceFiles caches the lines of the source files read by StacktraceWithSource
+var sourceFiles = newSourceCache(sourceCacheSize)
+
+func sourceLines(file string) []string {
+	if cached, ok := sourceFiles.Load(file); ok {
+		return cached
+	}
+
+	var lines []string
+	f, err := os.Open(file)
+	if err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		_ = f.Close()
+		if scanner.Err() != nil {
+			lines = nil
+		}
+	}
+	sourceFiles.Store(file, lines)
+
+	return lines
+}
+
+// writeSource writes the source lines around the frame's line, with the line of the frame marked
+// by '>'. Nothing is written if the source file is not available, e.g. in production binaries
+func writeSource(buff *bytes.Buffer, frame Frame, contextLines int) {
+	lines := sourceLines(frame.File)
+	if frame.Line < 1 || frame.Line > len(lines) {
+		return
+	}
+
+	from := max(frame.Line-contextLines, 1)
+	to := min(frame.Line+contextLines, len(lines))
+	width := len(strconv.Itoa(to))
+	for num := from; num <= to; num++ {
+		buff.WriteString("\t")
+		if num == frame.Line {
+			buff.WriteString("> ")
+		} else {
+			buff.WriteString("  ")
+		}
+		lineNum := strconv.Itoa(num)
+		buff.WriteString(strings.Repeat(" ", width-len(lineNum)))
+		buff.WriteString(lineNum)
+		buff.WriteString(" | ")
+		buff.WriteString(lines[num-1])
+		buff.WriteString("\n")
+	}
+}
+
+// StacktraceWithSource is same as Stacktrace, and also prints contextLines number of source lines
+// before & after the line of every in-app frame. Source files are read from the disk (and the most
+// recently used ones are cached), if a file is not available, only the file path & line is printed
+// for its frames. Frames are dropped & collapsed as per the TraceOptions, refer SetTraceOptions.
+func StacktraceWithSource(err error, contextLines int) string {
+	buff := bytes.NewBuffer(make([]byte, 0, 1024))
+	for _, line := range chainTraceLines(err, currentTraceOptions()) {
+		buff.WriteString(line.text)
+		buff.WriteString("\n")
+		if line.frame != nil && line.frame.InApp && contextLines >= 0 {
+			writeSource(buff, *line.frame, contextLines)
+		}
+	}
+
+	return strings.TrimSuffix(buff.String(), "\n")
+}
@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStacktraceWithSource(t *testing.T) {
+	err := Wrap(errors.New("original error"), "wrapped error")
+	got := StacktraceWithSource(err, 1)
+
+	lines := strings.Split(got, "\n")
+	if lines[0] != "github.com/naughtygopher/errors.TestStacktraceWithSource(): wrapped error" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "source_test.go:11") {
+		t.Errorf("unexpected trace %q", lines[1])
+	}
+	want := []string{
+		"\t  10 | func TestStacktraceWithSource(t *testing.T) {",
+		"\t> 11 | \terr := Wrap(errors.New(\"original error\"), \"wrapped error\")",
+		"\t  12 | \tgot := StacktraceWithSource(err, 1)",
+	}
+	for idx, line := range want {
+		if lines[idx+2] != line {
+			t.Errorf("line %d = %q, want %q", idx+2, lines[idx+2], line)
+		}
+	}
+	// stdlib frames do not have source lines
+	if !strings.Contains(lines[5], "testing.go:") || strings.Contains(lines[6], " | ") {
+		t.Errorf("unexpected stdlib frame %q", lines[5:7])
+	}
+	if lines[len(lines)-1] != "original error" {
+		t.Errorf("unexpected last line %q", lines[len(lines)-1])
+	}
+}
+
+func TestStacktraceWithSourceMissing(t *testing.T) {
+	frame := New("missing source").Frames()[0]
+	frame.File = "/does/not/exist.go"
+
+	buff := bytes.NewBuffer(nil)
+	writeSource(buff, frame, 3)
+	if buff.Len() != 0 {
+		t.Errorf("writeSource() = %q, want empty", buff.String())
+	}
+	if _, ok := sourceFiles.Load(frame.File); !ok {
+		t.Error("missing files should be cached")
+	}
+
+	frame = New("out of range").Frames()[0]
+	frame.Line = 100000
+	writeSource(buff, frame, 3)
+	if buff.Len() != 0 {
+		t.Errorf("writeSource() = %q, want empty", buff.String())
+	}
+}
+
+func TestSourceCache(t *testing.T) {
+	cache := newSourceCache(2)
+	cache.Store("a.go", []string{"a"})
+	cache.Store("b.go", []string{"b"})
+	if _, ok := cache.Load("a.go"); !ok {
+		t.Fatal("a.go should be cached")
+	}
+
+	// b.go is the least recently used, and is evicted
+	cache.Store("c.go", []string{"c"})
+	if _, ok := cache.Load("b.go"); ok {
+		t.Error("b.go should be evicted")
+	}
+	for _, file := range []string{"a.go", "c.go"} {
+		if _, ok := cache.Load(file); !ok {
+			t.Errorf("%s should be cached", file)
+		}
+	}
+}
+
+func TestStacktraceWithSourceOptions(t *testing.T) {
+	before := traceOptions.Load()
+	t.Cleanup(func() { traceOptions.Store(before) })
+
+	err := Wrap(errors.New("original error"), "wrapped error")
+
+	SetTraceOptions(TraceOptions{DropStdlib: true})
+	if got := StacktraceWithSource(err, 0); strings.Contains(got, "testing.go") {
+		t.Errorf("stdlib frames should be dropped: %s", got)
+	}
+
+	SetTraceOptions(TraceOptions{CollapsePrefixes: []string{"testing"}})
+	if got := StacktraceWithSource(err, 0); !strings.Contains(got, "\t... 1 frame(s) in testing\n") {
+		t.Errorf("frames should be collapsed: %s", got)
+	}
+}
+
+func TestStacktraceWithSourceMatchesStacktrace(t *testing.T) {
+	f := NewFactory(Config{Clock: fakeClock(new(Error).at.AddDate(2025, 0, 0), 1)})
+	err := f.Wrap(pkgErrorsSite(), "wrapped")
+
+	want := Stacktrace(err)
+	if !strings.Contains(want, "pkgErrorsSite") || !strings.Contains(want, "[2026-") {
+		t.Fatalf("Stacktrace() = %q, expected the foreign frames & timestamp", want)
+	}
+	if got := StacktraceWithSource(err, -1); got != want {
+		t.Errorf("StacktraceWithSource() = %q, want %q", got, want)
+	}
+
+	got := StacktraceWithSource(err, 0)
+	for _, line := range strings.Split(want, "\n") {
+		if !strings.Contains(got, line+"\n") && !strings.HasSuffix(got, line) {
+			t.Errorf("StacktraceWithSource() = %q, missing line %q", got, line)
+		}
+	}
+}
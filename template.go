@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// stackTemplate is a parsed format used to print stack frames, refer StackTraceCustomFormat for the
+// supported directives. The values are substituted in a single pass, so directives in the values
+// (e.g. a message with '%l') are printed as is.
+type stackTemplate []templateToken
+
+type templateToken struct {
+	literal   string
+	directive byte
+}
+
+func parseStackTemplate(format string) stackTemplate {
+	tokens := make(stackTemplate, 0, 8)
+	literal := bytes.NewBuffer(make([]byte, 0, len(format)))
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, templateToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for idx := 0; idx < len(format); idx++ {
+		char := format[idx]
+		if char != '%' || idx == len(format)-1 {
+			literal.WriteByte(char)
+			continue
+		}
+
+		switch directive := format[idx+1]; directive {
+		case 'm', 'p', 'l', 'f', 'k', 's', 'x', 't', 'c', 'a':
+			flush()
+			tokens = append(tokens, templateToken{directive: directive})
+			idx++
+		case '%':
+			// escaped '%', e.g. "%%m" is printed as "%m"
+			literal.WriteByte(char)
+			idx++
+		default:
+			// unsupported directives are printed as is
+			literal.WriteByte(char)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// render writes the template for the frame of the error e. If e is nil, err is a non *Error and
+// the message directive is substituted with the output of err.Error(). The frame directives are
+// empty if the frame is not available, e.g. for non *Error without stacktrace. For collapsed
+// frames, the function directive is substituted with the collapsed line and the others are empty
+func (st stackTemplate) render(buff *bytes.Buffer, opts TraceOptions, e *Error, err error, tf traceFrame) {
+	frame := tf.Frame
+	noFrame := frame.Function == "" && frame.File == ""
+	for _, token := range st {
+		if token.directive == 0 {
+			buff.WriteString(token.literal)
+			continue
+		}
+
+		switch token.directive {
+		case 'p', 'l', 'f', 'k', 's', 'x':
+			if noFrame {
+				continue
+			}
+			if tf.collapsed > 0 {
+				if token.directive == 'f' {
+					buff.WriteString(tf.collapsedLine())
+				}
+				continue
+			}
+		case 't', 'c', 'a':
+			if e == nil {
+				continue
+			}
+		}
+
+		switch token.directive {
+		case 'm':
+			if e == nil {
+				buff.WriteString(err.Error())
+				continue
+			}
+			buff.WriteString(e.text())
+		case 'p':
+			buff.WriteString(opts.path(frame.Function, frame.File))
+		case 'l':
+			buff.WriteString(strconv.Itoa(frame.Line))
+		case 'f':
+			buff.WriteString(frame.Function)
+		case 'k':
+			buff.WriteString(frame.Package)
+		case 's':
+			buff.WriteString(frame.ShortFile())
+		case 'x':
+			buff.WriteString("0x")
+			buff.WriteString(strconv.FormatUint(uint64(frame.PC), 16))
+		case 't':
+			buff.WriteString(e.eType.String())
+		case 'c':
+			buff.WriteString(e.code)
+		case 'a':
+			written := 0
+			for _, attr := range e.attrs {
+				if isDropped(attr.Value) {
+					continue
+				}
+				if written > 0 {
+					buff.WriteString(" ")
+				}
+				written++
+				buff.WriteString(attr.Key)
+				buff.WriteString("=")
+				buff.WriteString(fmt.Sprint(attr.Value))
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newPerFrameLinesErr() *Error {
+	return New("per frame lines")
+}
+
+func TestStackTraceNoFormatLines(t *testing.T) {
+	err := newPerFrameLinesErr()
+	frames := err.Frames()
+	trace := err.StackTraceNoFormat()
+	if len(trace) != len(frames) {
+		t.Fatalf("StackTraceNoFormat() = %q", trace)
+	}
+	for idx, frame := range frames[:len(frames)-1] {
+		want := frame.File + ":" + strconv.Itoa(frame.Line)
+		if trace[idx+1] != want {
+			t.Errorf("StackTraceNoFormat()[%d] = %q, want %q", idx+1, trace[idx+1], want)
+		}
+	}
+	if frames[0].Line == frames[1].Line {
+		t.Errorf("frames should have different lines: %d, %d", frames[0].Line, frames[1].Line)
+	}
+}
+
+func TestStackTraceCustomFormatDirectives(t *testing.T) {
+	err := NewWithCode("E42", "100%l sure", TypeNotFound)
+	err.attrs = []Attr{Attribute("user", "u1"), Attribute("tenant", "t1")}
+
+	trace := err.StackTraceCustomFormat("%t|%c|%m|%a|100%%|%z|", "%k %s:%l %x;")
+	want := "NotFound|E42|100%l sure|user=u1 tenant=t1|100%|%z|"
+	if trace[0] != want {
+		t.Errorf("StackTraceCustomFormat()[0] = %q, want %q", trace[0], want)
+	}
+
+	frames := err.Frames()
+	for idx, frame := range frames[:len(frames)-1] {
+		want := fmt.Sprintf("%s %s:%d 0x%x;", frame.Package, frame.ShortFile(), frame.Line, frame.PC)
+		if trace[idx+1] != want {
+			t.Errorf("StackTraceCustomFormat()[%d] = %q, want %q", idx+1, trace[idx+1], want)
+		}
+	}
+
+	got := StacktraceCustomFormat("%m (%t)\n", "\t%f:%l\n", Wrap(errors.New("std %l"), "wrapped"))
+	if !strings.HasPrefix(got, "wrapped (Internal)\n\tgithub.com/naughtygopher/errors.TestStackTraceCustomFormatDirectives:") ||
+		!strings.HasSuffix(got, "std %l ()\n") {
+		t.Errorf("StacktraceCustomFormat() = %q", got)
+	}
+}
+
+func TestStackTraceCustomFormatPercent(t *testing.T) {
+	// '%%' is an escaped '%', so that directives can be printed literally
+	err := New("failed")
+	tests := map[string]string{
+		"100%%":    "100%",
+		"%%m":      "%m",
+		"%%%m":     "%failed",
+		"%m 50%":   "failed 50%",
+		"%%d %m%%": "%d failed%",
+		"%%%%m":    "%%m",
+	}
+	for format, want := range tests {
+		if got := err.StackTraceCustomFormat(format, "")[0]; got != want {
+			t.Errorf("StackTraceCustomFormat(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package errors
+
+import "time"
+
+// Time returns the time when the error was created. It is the zero time, unless the factory used
+// to create the error has a Clock configured
+func (e *Error) Time() time.Time {
+	return e.at
+}
+
+// formattedTime returns the creation time in RFC3339 format with nanoseconds, empty string if not recorded
+func (e *Error) formattedTime() string {
+	if e.at.IsZero() {
+		return ""
+	}
+	return e.at.Format(time.RFC3339Nano)
+}
+
+// FirstTime returns the earliest creation time among all the *Error in the error tree, usually
+// the time when the root cause occurred. It is the zero time if none was recorded
+func FirstTime(err error) time.Time {
+	first := time.Time{}
+	walk(err, func(e *Error) bool {
+		if !e.at.IsZero() && (first.IsZero() || e.at.Before(first)) {
+			first = e.at
+		}
+		return true
+	})
+	return first
+}
+
+// LastTime returns the latest creation time among all the *Error in the error tree, usually the
+// time when the error was last wrapped. It is the zero time if none was recorded
+func LastTime(err error) time.Time {
+	last := time.Time{}
+	walk(err, func(e *Error) bool {
+		if e.at.After(last) {
+			last = e.at
+		}
+		return true
+	})
+	return last
+}
+
+// Elapsed returns the duration between FirstTime and LastTime of the error, i.e. the time taken for
+// the root cause to surface
+func Elapsed(err error) time.Duration {
+	return LastTime(err).Sub(FirstTime(err))
+}
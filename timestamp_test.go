@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns times starting at start, advancing by step on every call
+func fakeClock(start time.Time, step time.Duration) func() time.Time {
+	now := start.Add(-step)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestTimestamps(t *testing.T) {
+	start := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	f := NewFactory(Config{Clock: fakeClock(start, time.Second)})
+
+	root := f.New("connection refused")
+	mid := f.Wrap(root, "query failed")
+	top := f.Wrap(Join(errors.New("std"), mid), "get user")
+
+	if !root.Time().Equal(start) {
+		t.Errorf("Time() = %v, want %v", root.Time(), start)
+	}
+	if got := FirstTime(top); !got.Equal(start) {
+		t.Errorf("FirstTime() = %v, want %v", got, start)
+	}
+	if got := LastTime(top); !got.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("LastTime() = %v, want %v", got, start.Add(2*time.Second))
+	}
+	if got := Elapsed(top); got != 2*time.Second {
+		t.Errorf("Elapsed() = %v, want %v", got, 2*time.Second)
+	}
+
+	verbose := fmt.Sprintf("%+v", mid)
+	for _, want := range []string{"[2025-07-01T10:00:01Z] query failed", "[2025-07-01T10:00:00Z] connection refused"} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("%%+v = %q, expected to contain %q", verbose, want)
+		}
+	}
+
+	trace := Stacktrace(mid)
+	if !strings.Contains(trace, "(): query failed [2025-07-01T10:00:01Z]") ||
+		!strings.Contains(trace, "(): connection refused [2025-07-01T10:00:00Z]") {
+		t.Errorf("Stacktrace() = %q, expected timestamps", trace)
+	}
+
+	raw, err := json.Marshal(mid)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(raw), `"time":"2025-07-01T10:00:01Z"`) ||
+		!strings.Contains(string(raw), `"time":"2025-07-01T10:00:00Z"`) {
+		t.Errorf("json.Marshal() = %s, expected timestamps", raw)
+	}
+}
+
+func TestTimestampsDisabled(t *testing.T) {
+	err := Wrap(New("hello"), "world")
+	if !err.Time().IsZero() || !FirstTime(err).IsZero() || !LastTime(err).IsZero() || Elapsed(err) != 0 {
+		t.Errorf("timestamps should not be recorded by default")
+	}
+	if got := fmt.Sprintf("%+v", err); got != err.Error() {
+		t.Errorf("%%+v = %q, want %q", got, err.Error())
+	}
+	if raw, _ := json.Marshal(err); strings.Contains(string(raw), `"time"`) {
+		t.Errorf("json.Marshal() = %s, expected no timestamps", raw)
+	}
+	if !FirstTime(nil).IsZero() {
+		t.Errorf("FirstTime(nil) should be zero")
+	}
+}
+
+func TestErrorWithTimeMatchesError(t *testing.T) {
+	start := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	f := NewFactory(Config{Clock: fakeClock(start, time.Second)})
+	err := f.Wrap(f.Wrap(errors.New("std"), "query failed"), "get user")
+
+	// the only difference between the formats is the timestamps
+	verbose := fmt.Sprintf("%+v", err)
+	for _, at := range []string{"[2025-07-01T10:00:00Z] ", "[2025-07-01T10:00:01Z] "} {
+		verbose = strings.Replace(verbose, at, "", 1)
+	}
+	if verbose != err.Error() {
+		t.Errorf("%%+v without timestamps = %q, want %q", verbose, err.Error())
+	}
+}
@@ -0,0 +1,173 @@
+package errors
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PathMode defines how file paths are printed in Error(), stacktraces etc.
+type PathMode int
+
+const (
+	// PathAbsolute prints the absolute file path as available in the binary, this is the default
+	PathAbsolute PathMode = iota
+	// PathRelative prints the file path relative to GOROOT/src, GOPATH/src, GOPATH/pkg/mod or the
+	// root of the module (directory with go.mod) of the file, whichever is applicable
+	PathRelative
+	// PathModule prints the module qualified file path, e.g. github.com/naughtygopher/errors/errors.go
+	PathModule
+)
+
+// TraceOptions are the options used while printing file paths & stack frames
+type TraceOptions struct {
+	PathMode PathMode
+	// DropRuntime drops the frames of the Go runtime, e.g. runtime.goexit
+	DropRuntime bool
+	// DropStdlib drops the frames of the Go standard library, including the runtime
+	DropStdlib bool
+	// CollapsePrefixes are package path prefixes, consecutive frames of matching packages are
+	// collapsed into a single line. e.g. frames of HTTP middleware libraries
+	CollapsePrefixes []string
+}
+
+var traceOptions = atomic.Pointer[TraceOptions]{}
+
+// SetTraceOptions sets the global TraceOptions, which are used by Error(), StackTrace(), Stacktrace() etc.
+func SetTraceOptions(opts TraceOptions) {
+	traceOptions.Store(&opts)
+}
+
+func currentTraceOptions() TraceOptions {
+	opts := traceOptions.Load()
+	if opts == nil {
+		return TraceOptions{}
+	}
+	return *opts
+}
+
+// path returns the file path as per the PathMode
+func (opts TraceOptions) path(function string, file string) string {
+	switch opts.PathMode {
+	case PathRelative:
+		return relativePath(file)
+	case PathModule:
+		return modulePath(function, file)
+	}
+	return file
+}
+
+// traceFrame is a stack frame, which represents 'collapsed' consecutive frames if collapsed > 0
+type traceFrame struct {
+	Frame
+	collapsed int
+	prefix    string
+}
+
+// filter drops & collapses the frames as per the options
+func (opts TraceOptions) filter(frames []Frame) []traceFrame {
+	list := make([]traceFrame, 0, len(frames))
+	for _, frame := range frames {
+		list = opts.appendFrame(list, frame)
+	}
+	return list
+}
+
+func (opts TraceOptions) appendFrame(list []traceFrame, frame Frame) []traceFrame {
+	// the entry point of every goroutine, it's not printed irrespective of the options
+	if frame.Function == "runtime.goexit" {
+		return list
+	}
+
+	pkg := frame.Package
+	if opts.DropStdlib && isStdlib(pkg) {
+		return list
+	}
+	if opts.DropRuntime && (pkg == "runtime" || strings.HasPrefix(pkg, "runtime/")) {
+		return list
+	}
+
+	prefix := ""
+	for _, p := range opts.CollapsePrefixes {
+		if pkg == p || strings.HasPrefix(pkg, strings.TrimSuffix(p, "/")+"/") {
+			prefix = p
+			break
+		}
+	}
+	if prefix == "" {
+		return append(list, traceFrame{Frame: frame})
+	}
+
+	if last := len(list) - 1; last >= 0 && list[last].prefix == prefix {
+		list[last].collapsed++
+		return list
+	}
+
+	return append(list, traceFrame{Frame: frame, collapsed: 1, prefix: prefix})
+}
+
+// collapsedLine is the line printed in place of collapsed frames
+func (tf traceFrame) collapsedLine() string {
+	return "... " + strconv.Itoa(tf.collapsed) + " frame(s) in " + tf.prefix
+}
+
+// isStdlib returns true if the package is part of the Go standard library, i.e. the first element
+// of the import path does not have a dot
+func isStdlib(pkg string) bool {
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
+
+var (
+	// moduleRoots caches the module root of directories
+	moduleRoots = sync.Map{}
+	goroot      = filepath.ToSlash(filepath.Join(runtime.GOROOT(), "src")) + "/"
+)
+
+func relativePath(file string) string {
+	if rel, ok := strings.CutPrefix(file, goroot); ok {
+		return rel
+	}
+
+	for _, gopath := range filepath.SplitList(build.Default.GOPATH) {
+		gopath = filepath.ToSlash(gopath)
+		if rel, ok := strings.CutPrefix(file, gopath+"/pkg/mod/"); ok {
+			return rel
+		}
+		if rel, ok := strings.CutPrefix(file, gopath+"/src/"); ok {
+			return rel
+		}
+	}
+
+	dir := filepath.Dir(file)
+	root := moduleRoot(dir)
+	if root == "" {
+		return file
+	}
+	return strings.TrimPrefix(file, root+"/")
+}
+
+// moduleRoot returns the closest parent directory which has a go.mod file
+func moduleRoot(dir string) string {
+	if cached, ok := moduleRoots.Load(dir); ok {
+		return cached.(string)
+	}
+
+	root := ""
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		root = filepath.ToSlash(dir)
+	} else if parent := filepath.Dir(dir); parent != dir {
+		root = moduleRoot(parent)
+	}
+	moduleRoots.Store(dir, root)
+
+	return root
+}
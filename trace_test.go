@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func setTestTraceOptions(t *testing.T, opts TraceOptions) {
+	t.Helper()
+	before := traceOptions.Load()
+	t.Cleanup(func() { traceOptions.Store(before) })
+	SetTraceOptions(opts)
+}
+
+func TestPathMode(t *testing.T) {
+	err := New("path mode")
+
+	setTestTraceOptions(t, TraceOptions{PathMode: PathModule})
+	if got := err.Error(); !strings.HasPrefix(got, "github.com/naughtygopher/errors/trace_test.go:") {
+		t.Errorf("Error() = %q", got)
+	}
+
+	SetTraceOptions(TraceOptions{PathMode: PathRelative})
+	if got := err.Error(); !strings.HasPrefix(got, "trace_test.go:") {
+		t.Errorf("Error() = %q", got)
+	}
+	trace := err.StackTrace()
+	if !strings.HasPrefix(trace[1], "\ttrace_test.go:") {
+		t.Errorf("StackTrace() = %q", trace)
+	}
+	// testing package is part of GOROOT
+	if !strings.HasPrefix(trace[2], "\ttesting/testing.go:") {
+		t.Errorf("StackTrace() = %q", trace)
+	}
+
+	SetTraceOptions(TraceOptions{})
+	if got := err.Error(); !strings.HasPrefix(got, "/") {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestStacktraceWithOptions(t *testing.T) {
+	err := Wrap(errors.New("original"), "wrapped")
+
+	got := StacktraceWithOptions(err, TraceOptions{DropRuntime: true})
+	if strings.Contains(got, "runtime/") {
+		t.Errorf("runtime frames should be dropped: %s", got)
+	}
+	if !strings.Contains(got, "testing.go") {
+		t.Errorf("stdlib frames should not be dropped: %s", got)
+	}
+
+	got = StacktraceWithOptions(err, TraceOptions{DropStdlib: true, PathMode: PathModule})
+	lines := strings.Split(got, "\n")
+	want := []string{
+		"github.com/naughtygopher/errors.TestStacktraceWithOptions(): wrapped",
+		"\tgithub.com/naughtygopher/errors/trace_test.go:",
+		"original",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("StacktraceWithOptions() = %q", lines)
+	}
+	for idx, line := range lines {
+		if !strings.HasPrefix(line, want[idx]) {
+			t.Errorf("line %d = %q, want prefix %q", idx, line, want[idx])
+		}
+	}
+
+	got = StacktraceWithOptions(err, TraceOptions{CollapsePrefixes: []string{"testing", "runtime"}})
+	if !strings.Contains(got, "\t... 1 frame(s) in testing\n") {
+		t.Errorf("frames should be collapsed: %s", got)
+	}
+	// runtime.goexit is never part of the stacktrace, so there's nothing to collapse
+	if strings.Contains(got, "frame(s) in runtime") {
+		t.Errorf("runtime.goexit should not be printed: %s", got)
+	}
+}
+
+func TestIsStdlib(t *testing.T) {
+	tests := map[string]bool{
+		"net/http":                        true,
+		"runtime":                         true,
+		"main":                            false,
+		"github.com/naughtygopher/errors": false,
+		"":                                false,
+	}
+	for pkg, want := range tests {
+		if got := isStdlib(pkg); got != want {
+			t.Errorf("isStdlib(%q) = %v, want %v", pkg, got, want)
+		}
+	}
+}
+
+func TestTraceOptionsFormatters(t *testing.T) {
+	err := Wrap(errors.New("original"), "wrapped")
+	tests := []struct {
+		name   string
+		format func(err error) string
+	}{
+		{
+			name:   "StacktraceNoFormat",
+			format: func(err error) string { return strings.Join(StacktraceNoFormat(err), "\n") },
+		},
+		{
+			name:   "StacktraceCustomFormat",
+			format: func(err error) string { return StacktraceCustomFormat("%m\n", "\t%f %s:%l\n", err) },
+		},
+		{
+			name:   "StacktraceFromPcs",
+			format: StacktraceFromPcs,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestTraceOptions(t, TraceOptions{DropStdlib: true})
+			got := tt.format(err)
+			if strings.Contains(got, "testing.tRunner") || strings.Contains(got, "testing.go") {
+				t.Errorf("stdlib frames should be dropped: %q", got)
+			}
+			if !strings.Contains(got, "TestTraceOptionsFormatters") {
+				t.Errorf("frames of the test should not be dropped: %q", got)
+			}
+
+			SetTraceOptions(TraceOptions{CollapsePrefixes: []string{"testing"}})
+			got = tt.format(err)
+			if !strings.Contains(got, "... 1 frame(s) in testing") || strings.Contains(got, "testing.tRunner") {
+				t.Errorf("frames should be collapsed: %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+)
+
+// ColorMode defines when ANSI colors are used by FprintTree
+type ColorMode int
+
+const (
+	// ColorAuto enables colors only if the output is a terminal, and the NO_COLOR environment
+	// variable is not set
+	ColorAuto ColorMode = iota
+	// ColorAlways always enables colors
+	ColorAlways
+	// ColorNever disables colors
+	ColorNever
+)
+
+const (
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+	ansiRed   = "\033[31m"
+	ansiDim   = "\033[2m"
+)
+
+// TreeOptions are the options used by FprintTree
+type TreeOptions struct {
+	Color ColorMode
+}
+
+// Tree returns the tree representation (without colors) of the error, refer FprintTree
+func Tree(err error) string {
+	buff := bytes.NewBuffer(make([]byte, 0, 256))
+	_ = FprintTree(buff, err, TreeOptions{Color: ColorNever})
+	return buff.String()
+}
+
+// FprintTree writes a human friendly tree representation of the error to w. Every wrapped error is
+// a child of the error wrapping it, and every joined error is a sibling. Each *Error is printed
+// with its type name, message and the module qualified path of the file where it was created, e.g.
+//
+//	Internal: get user [github.com/org/app/user.go:42]
+//	└── NotFound: user not found [github.com/org/app/store.go:21]
+func FprintTree(w io.Writer, err error, opts TreeOptions) error {
+	if err == nil {
+		return nil
+	}
+	tp := treePrinter{
+		buff:  bytes.NewBuffer(make([]byte, 0, 256)),
+		color: useColor(w, opts.Color),
+	}
+	tp.node(err, "", "", "")
+	_, werr := w.Write(tp.buff.Bytes())
+	return werr
+}
+
+func useColor(w io.Writer, mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+type treePrinter struct {
+	buff  *bytes.Buffer
+	color bool
+}
+
+func (tp *treePrinter) colored(code string, s string) {
+	if !tp.color {
+		tp.buff.WriteString(s)
+		return
+	}
+	tp.buff.WriteString(code)
+	tp.buff.WriteString(s)
+	tp.buff.WriteString(ansiReset)
+}
+
+// node prints err and its descendants. connector is printed right before the error, and prefix
+// is printed before all its descendants
+func (tp *treePrinter) node(err error, indent string, connector string, prefix string) {
+	tp.buff.WriteString(indent)
+	tp.buff.WriteString(connector)
+
+	var children []error
+	switch e := unembed(err).(type) {
+	case *Error:
+		tp.colored(ansiBold+ansiRed, e.eType.String())
+		tp.buff.WriteString(": ")
+		message := e.text()
+		if message == "" && e.original == nil {
+			message = DefaultMessage
+		}
+		tp.buff.WriteString(message)
+		if location := e.shortFileLine(); location != "" {
+			tp.buff.WriteString(" ")
+			tp.colored(ansiDim, "["+location+"]")
+		}
+		if e.original != nil {
+			children = []error{e.original}
+		}
+	case interface{ Unwrap() []error }:
+		tp.colored(ansiBold, fmt.Sprintf("%T", err))
+		tp.buff.WriteString(" (")
+		tp.buff.WriteString(strconv.Itoa(len(e.Unwrap())))
+		tp.buff.WriteString(" errors)")
+		children = e.Unwrap()
+	default:
+		tp.colored(ansiBold, fmt.Sprintf("%T", err))
+		tp.buff.WriteString(": ")
+		tp.buff.WriteString(err.Error())
+		if inner := Unwrap(err); inner != nil {
+			children = []error{inner}
+		}
+	}
+	tp.buff.WriteString("\n")
+
+	for idx, child := range children {
+		if child == nil {
+			continue
+		}
+		if idx == len(children)-1 {
+			tp.node(child, prefix, "└── ", prefix+"    ")
+		} else {
+			tp.node(child, prefix, "├── ", prefix+"│   ")
+		}
+	}
+}
+
+// shortFileLine is same as fileLine, but with the module qualified file path
+func (e *Error) shortFileLine() string {
+	if e.pc == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames([]uintptr{e.pc + 1})
+	frame, _ := frames.Next()
+	return modulePath(frame.Function, frame.File) + ":" + strconv.Itoa(frame.Line)
+}
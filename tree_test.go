@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	inner := NotFound("user not found")
+	std := fmt.Errorf("cache: %w", errors.New("connection refused"))
+	err := Wrap(Join(inner, std), "get user")
+
+	lines := regexp.MustCompile(`:\d+\]`)
+	got := lines.ReplaceAllString(Tree(err), ":N]")
+	want := strings.Join([]string{
+		"Internal: get user [github.com/naughtygopher/errors/tree_test.go:N]",
+		"└── *errors.joinError (2 errors)",
+		"    ├── NotFound: user not found [github.com/naughtygopher/errors/tree_test.go:N]",
+		"    └── *fmt.wrapError: cache: connection refused",
+		"        └── *errors.errorString: connection refused",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+
+	if Tree(nil) != "" {
+		t.Error("Tree(nil) should be empty")
+	}
+}
+
+func TestFprintTreeColor(t *testing.T) {
+	err := Validation("invalid email")
+
+	buff := bytes.NewBuffer(nil)
+	_ = FprintTree(buff, err, TreeOptions{Color: ColorAlways})
+	if !strings.Contains(buff.String(), ansiBold+ansiRed+"Validation"+ansiReset) {
+		t.Errorf("FprintTree() = %q, expected colors", buff.String())
+	}
+
+	buff.Reset()
+	_ = FprintTree(buff, err, TreeOptions{Color: ColorAuto})
+	if strings.Contains(buff.String(), "\033[") {
+		t.Errorf("FprintTree() = %q, colors should be disabled for non terminal", buff.String())
+	}
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	typesMu = sync.RWMutex{}
+	// typeNames holds the names of the built-in as well as the registered error types
+	typeNames = []string{
+		TypeInternal:                     "Internal",
+		TypeValidation:                   "Validation",
+		TypeInputBody:                    "InputBody",
+		TypeDuplicate:                    "Duplicate",
+		TypeUnauthenticated:              "Unauthenticated",
+		TypeUnauthorized:                 "Unauthorized",
+		TypeEmpty:                        "Empty",
+		TypeNotFound:                     "NotFound",
+		TypeMaximumAttempts:              "MaximumAttempts",
+		TypeSubscriptionExpired:          "SubscriptionExpired",
+		TypeDownstreamDependencyTimedout: "DownstreamDependencyTimedout",
+		TypeNotImplemented:               "NotImplemented",
+		TypeContextTimedout:              "ContextTimedout",
+		TypeContextCancelled:             "ContextCancelled",
+	}
+)
+
+func typeName(et errType) (string, bool) {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	if et < 0 || int(et) >= len(typeNames) {
+		return "", false
+	}
+	return typeNames[et], true
+}
+
+// RegisterType registers a new error type with the given name and returns it. The name
+// must be unique (case insensitive) among built-in and registered types, otherwise an error is
+// returned along with the already existing type of the same name.
+// Registered types are treated as TypeInternal by the HTTP & GRPC status code mappers.
+func RegisterType(name string) (errType, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errType(-1), fmt.Errorf("errors: type name cannot be empty")
+	}
+
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	for idx, existing := range typeNames {
+		if strings.EqualFold(existing, name) {
+			return errType(idx), fmt.Errorf("errors: type %q is already registered", name)
+		}
+	}
+	typeNames = append(typeNames, name)
+
+	return errType(len(typeNames) - 1), nil
+}
+
+// ParseType returns the error type of the given name. The lookup is case insensitive and the
+// "Type" prefix is optional, i.e. "NotFound", "notfound" and "TypeNotFound" are all TypeNotFound
+func ParseType(name string) (errType, error) {
+	name = strings.TrimSpace(name)
+	trimmed := name
+	if len(trimmed) > 4 && strings.EqualFold(trimmed[:4], "type") {
+		trimmed = trimmed[4:]
+	}
+
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	for idx, existing := range typeNames {
+		if strings.EqualFold(existing, name) || strings.EqualFold(existing, trimmed) {
+			return errType(idx), nil
+		}
+	}
+
+	return errType(-1), fmt.Errorf("errors: unknown error type %q", name)
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestErrTypeString(t *testing.T) {
+	tests := []struct {
+		name string
+		et   errType
+		want string
+	}{
+		{name: "TypeInternal", et: TypeInternal, want: "Internal"},
+		{name: "TypeNotFound", et: TypeNotFound, want: "NotFound"},
+		{name: "TypeContextCancelled", et: TypeContextCancelled, want: "ContextCancelled"},
+		{name: "unknown", et: errType(-1), want: "errType(-1)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.et.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    errType
+		wantErr bool
+	}{
+		{name: "exact", input: "NotFound", want: TypeNotFound},
+		{name: "case insensitive", input: "notfound", want: TypeNotFound},
+		{name: "type prefix", input: "TypeDuplicate", want: TypeDuplicate},
+		{name: "unknown", input: "Foo", want: errType(-1), wantErr: true},
+		{name: "empty", input: "", want: errType(-1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseType(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	et, err := RegisterType("PaymentDeclined")
+	if err != nil {
+		t.Fatalf("RegisterType() error = %v", err)
+	}
+	if et.String() != "PaymentDeclined" {
+		t.Errorf("String() = %q, want %q", et.String(), "PaymentDeclined")
+	}
+
+	parsed, err := ParseType("PaymentDeclined")
+	if err != nil || parsed != et {
+		t.Errorf("ParseType() = %v, %v, want %v", parsed, err, et)
+	}
+
+	existing, err := RegisterType("paymentdeclined")
+	if err == nil {
+		t.Error("RegisterType() expected error for duplicate name")
+	}
+	if existing != et {
+		t.Errorf("RegisterType() = %v, want %v", existing, et)
+	}
+
+	_, err = RegisterType("notfound")
+	if err == nil {
+		t.Error("RegisterType() expected error for built-in name")
+	}
+}
+
+func TestErrTypeText(t *testing.T) {
+	payload := struct {
+		Type errType `json:"type"`
+	}{Type: TypeNotFound}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"type":"NotFound"}`
+	if string(raw) != want {
+		t.Errorf("json.Marshal() = %s, want %s", raw, want)
+	}
+
+	payload.Type = TypeInternal
+	err = json.Unmarshal([]byte(`{"type":"Unauthorized"}`), &payload)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if payload.Type != TypeUnauthorized {
+		t.Errorf("json.Unmarshal() = %v, want %v", payload.Type, TypeUnauthorized)
+	}
+
+	err = json.Unmarshal([]byte(`{"type":"Bogus"}`), &payload)
+	if err == nil {
+		t.Error("json.Unmarshal() expected error for unknown type")
+	}
+
+	raw, err = json.Marshal(NewWithType("unknown", 99))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(raw), `"type":"errType(99)"`) {
+		t.Errorf("json.Marshal() = %s, expected the type as errType(99)", raw)
+	}
+
+	err = json.Unmarshal([]byte(`{"type":"errType(99)"}`), &payload)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if payload.Type != errType(99) {
+		t.Errorf("json.Unmarshal() = %v, want %v", payload.Type, errType(99))
+	}
+}